@@ -1,37 +1,175 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/ardhia137/task_todo/src/config"
 	"github.com/ardhia137/task_todo/src/database"
-	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/email"
+	"github.com/ardhia137/task_todo/src/escalation"
+	"github.com/ardhia137/task_todo/src/handlers"
+	"github.com/ardhia137/task_todo/src/jobs"
+	"github.com/ardhia137/task_todo/src/logging"
+	"github.com/ardhia137/task_todo/src/migrations"
+	"github.com/ardhia137/task_todo/src/notify"
+	"github.com/ardhia137/task_todo/src/repository"
 	"github.com/ardhia137/task_todo/src/routers"
+	"github.com/ardhia137/task_todo/src/scheduler"
 	seed "github.com/ardhia137/task_todo/src/seeder"
-	"github.com/joho/godotenv"
+	"github.com/ardhia137/task_todo/src/service"
+	"github.com/ardhia137/task_todo/src/storage"
+	"github.com/ardhia137/task_todo/src/stream"
+	"github.com/ardhia137/task_todo/src/webhook"
+	"github.com/ardhia137/task_todo/src/workflow"
 )
 
 func main() {
 
-	err := godotenv.Load()
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	}
 	if err != nil {
-		log.Fatalf("Error loading .env file: %v", err)
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+	config.Current = cfg
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "migrate":
+			runMigrate(args[1:])
+		case "config":
+			for _, line := range cfg.Redacted() {
+				fmt.Println(line)
+			}
+		default:
+			log.Fatalf("Unknown command '%s' (available: migrate, config)", args[0])
+		}
+		return
+	}
+
+	slog.SetDefault(logging.New(os.Stdout, cfg.Log))
+
+	if err := workflow.Load(cfg.App.WorkflowFile); err != nil {
+		fatal("error loading workflow", err)
+	}
+
+	if err := escalation.Load(cfg.App.EscalationFile); err != nil {
+		fatal("error loading escalation rules", err)
+	}
+
+	if err := escalation.Current.Validate(workflow.Current); err != nil {
+		fatal("error validating escalation rules", err)
+	}
+
+	if err := storage.Init(); err != nil {
+		fatal("error initializing storage", err)
 	}
 
 	database.Connect()
 
-	if err := database.DB.AutoMigrate(&model.User{}, &model.Task{}, &model.TaskHistory{}); err != nil {
-		log.Fatalf("Error during auto-migration: %v", err)
+	if err := migrations.Check(database.DB); err != nil {
+		fatal("refusing to start, run `go run . migrate up`", err)
 	}
 	seed.SeedUsers(database.DB)
-	r := routers.SetupRouter()
 
-	port := os.Getenv("APP_PORT")
-	if port == "" {
-		port = "8082"
+	repository.Default = repository.NewGormRepositories(database.DB)
+	service.Default = service.NewTaskService(repository.Default.Tasks, repository.Default.Users)
+
+	notify.Register(notify.LogNotifier{})
+	notify.Register(notify.InboxNotifier{DB: database.DB})
+	notify.Register(webhook.Notifier{DB: database.DB})
+
+	streamCtx, stopStream := context.WithCancel(logging.With(context.Background(), "component", "stream"))
+	stream.Default = stream.NewHub(database.DB, cfg.SSE.PollInterval)
+	if err := stream.Default.Start(streamCtx); err != nil {
+		fatal("error starting event stream", err)
+	}
+
+	var mailer email.Sender
+	if cfg.SMTP.Host != "" {
+		smtpSender, err := email.NewSMTP(email.SMTPConfigFrom(cfg.SMTP))
+		if err != nil {
+			fatal("error configuring email", err)
+		}
+		mailer = smtpSender
+		notify.Register(email.Notifier{DB: database.DB})
 	}
-	if err := r.Run(fmt.Sprintf(":%s", port)); err != nil {
-		log.Fatalf("Error starting server: %v", err)
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	jobScheduler := scheduler.New()
+	if !cfg.Scheduler.Disabled {
+		jobScheduler.Add(jobs.DeadlineJob(database.DB))
+		jobScheduler.Add(jobs.EscalationJob(database.DB))
+		jobScheduler.Add(jobs.WebhookJob(database.DB))
+		if mailer != nil {
+			jobScheduler.Add(jobs.EmailJob(database.DB, mailer))
+		}
+		jobScheduler.Start(jobsCtx)
+	}
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.App.Port),
+		Handler:           routers.SetupRouter(),
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
+	srv.RegisterOnShutdown(stopStream)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("listening", "addr", srv.Addr)
+		serverErr <- srv.ListenAndServe()
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			fatal("error starting server", err)
+		}
+	case sig := <-signals:
+		slog.Info("shutting down", "signal", sig.String(), "timeout", cfg.HTTP.ShutdownTimeout)
+	}
+
+	handlers.MarkShuttingDown()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("HTTP server did not drain in time", "error", err)
+	}
+
+	stopJobs()
+	jobsDone := make(chan struct{})
+	go func() {
+		jobScheduler.Wait()
+		close(jobsDone)
+	}()
+	select {
+	case <-jobsDone:
+	case <-shutdownCtx.Done():
+		slog.Warn("background jobs did not stop in time")
 	}
+
+	if sqlDB, err := database.DB.DB(); err == nil {
+		sqlDB.Close()
+	}
+	slog.Info("shutdown complete")
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ardhia137/task_todo/src/database"
+	"github.com/ardhia137/task_todo/src/migrations"
+)
+
+const migrateUsage = "usage: go run . migrate up|down|status"
+
+func runMigrate(args []string) {
+	if len(args) != 1 {
+		log.Fatal(migrateUsage)
+	}
+
+	database.Connect()
+
+	switch args[0] {
+	case "up":
+		ran, err := migrations.Up(database.DB)
+		for _, m := range ran {
+			fmt.Printf("applied %04d %s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("Error applying migrations: %v", err)
+		}
+		if len(ran) == 0 {
+			fmt.Println("schema is up to date")
+		}
+	case "down":
+		m, err := migrations.Down(database.DB)
+		if err != nil {
+			log.Fatalf("Error reverting migration: %v", err)
+		}
+		fmt.Printf("reverted %04d %s\n", m.Version, m.Name)
+	case "status":
+		statuses, err := migrations.List(database.DB)
+		if err != nil {
+			log.Fatalf("Error reading migration status: %v", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d %-30s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+}
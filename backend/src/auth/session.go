@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/utils"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+	ErrSessionRevoked      = errors.New("session has been revoked or has expired")
+	ErrUserInactive        = errors.New("user account is deactivated")
+)
+
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+	Role         string
+}
+
+func IssueSession(ctx context.Context, sessions repository.SessionRepository, user model.User) (TokenPair, error) {
+	rawRefresh, err := utils.RandomToken(32)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	session := model.Session{
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL()),
+	}
+	refresh := model.RefreshToken{
+		TokenHash: utils.HashToken(rawRefresh),
+		ExpiresAt: session.ExpiresAt,
+	}
+	if err := sessions.Create(ctx, &session, &refresh); err != nil {
+		return TokenPair{}, err
+	}
+
+	return tokenPair(user, session, rawRefresh)
+}
+
+func Refresh(ctx context.Context, sessions repository.SessionRepository, rawToken string) (TokenPair, error) {
+	token, err := sessions.FindRefreshToken(ctx, utils.HashToken(rawToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return TokenPair{}, ErrInvalidRefreshToken
+		}
+		return TokenPair{}, err
+	}
+
+	now := time.Now()
+	if token.UsedAt != nil {
+		sessions.Revoke(ctx, token.SessionID, now)
+		return TokenPair{}, ErrRefreshTokenReused
+	}
+	if token.Session.RevokedAt != nil || now.After(token.ExpiresAt) || now.After(token.Session.ExpiresAt) {
+		return TokenPair{}, ErrSessionRevoked
+	}
+	if !token.Session.User.Active {
+		return TokenPair{}, ErrUserInactive
+	}
+
+	rawRefresh, err := utils.RandomToken(32)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	session := token.Session
+	session.ExpiresAt = now.Add(utils.RefreshTokenTTL())
+	next := model.RefreshToken{
+		TokenHash: utils.HashToken(rawRefresh),
+		ExpiresAt: session.ExpiresAt,
+	}
+	if err := sessions.Rotate(ctx, token, now, &next); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			sessions.Revoke(ctx, token.SessionID, now)
+			return TokenPair{}, ErrRefreshTokenReused
+		}
+		return TokenPair{}, err
+	}
+
+	return tokenPair(session.User, session, rawRefresh)
+}
+
+func ValidateSession(ctx context.Context, sessions repository.SessionRepository, sessionID uint, userID uint) error {
+	session, err := sessions.Find(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSessionRevoked
+		}
+		return err
+	}
+
+	if session.UserID != userID || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return ErrSessionRevoked
+	}
+	if !session.User.Active {
+		return ErrUserInactive
+	}
+	return nil
+}
+
+func RevokeSession(ctx context.Context, sessions repository.SessionRepository, sessionID uint) error {
+	return sessions.Revoke(ctx, sessionID, time.Now())
+}
+
+func tokenPair(user model.User, session model.Session, rawRefresh string) (TokenPair, error) {
+	accessToken, err := utils.GenerateJWT(user.ID, user.Username, user.Role, session.ID)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: rawRefresh,
+		ExpiresIn:    int64(utils.AccessTokenTTL().Seconds()),
+		Role:         user.Role,
+	}, nil
+}
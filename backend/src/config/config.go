@@ -0,0 +1,467 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+type App struct {
+	Port           int
+	WorkflowFile   string
+	EscalationFile string
+}
+
+type HTTP struct {
+	ReadHeaderTimeout time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+	ReadinessTimeout  time.Duration
+}
+
+type Database struct {
+	Driver          string
+	Host            string
+	Port            string
+	User            string
+	Password        string
+	Name            string
+	SSLMode         string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+type Auth struct {
+	JWTSecret        string
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+	PasswordResetTTL time.Duration
+}
+
+type Password struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+type CORS struct {
+	AllowOrigins []string
+}
+
+type Storage struct {
+	Driver      string
+	LocalPath   string
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+type Attachment struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+type Comment struct {
+	EditWindow   time.Duration
+	DeleteWindow time.Duration
+}
+
+type SMTP struct {
+	Host     string
+	Port     string
+	From     string
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+type Delivery struct {
+	Interval     time.Duration
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+type Webhook struct {
+	Delivery
+	Timeout time.Duration
+}
+
+type SSE struct {
+	PollInterval      time.Duration
+	HeartbeatInterval time.Duration
+	Retry             time.Duration
+}
+
+type Scheduler struct {
+	Disabled           bool
+	DeadlineInterval   time.Duration
+	ReminderBefore     time.Duration
+	EscalationInterval time.Duration
+}
+
+type Log struct {
+	Level  string
+	Format string
+}
+
+type Config struct {
+	App        App
+	HTTP       HTTP
+	Log        Log
+	Database   Database
+	Auth       Auth
+	Password   Password
+	CORS       CORS
+	Storage    Storage
+	Attachment Attachment
+	Comment    Comment
+	SMTP       SMTP
+	Email      Delivery
+	Webhook    Webhook
+	SSE        SSE
+	Scheduler  Scheduler
+}
+
+var Current = Default()
+
+func Default() *Config {
+	return &Config{
+		App: App{Port: 8082},
+		HTTP: HTTP{
+			ReadHeaderTimeout: 10 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   30 * time.Second,
+			ReadinessTimeout:  2 * time.Second,
+		},
+		Log:      Log{Level: "info", Format: "json"},
+		Database: Database{Driver: "mysql", Host: "localhost", SSLMode: "disable", MaxIdleConns: 2},
+		Auth: Auth{
+			AccessTokenTTL:   15 * time.Minute,
+			RefreshTokenTTL:  7 * 24 * time.Hour,
+			PasswordResetTTL: time.Hour,
+		},
+		Password:   Password{MinLength: 8},
+		CORS:       CORS{AllowOrigins: []string{"*"}},
+		Storage:    Storage{Driver: "local", LocalPath: "./uploads"},
+		Attachment: Attachment{MaxSize: 10 << 20},
+		Comment:    Comment{EditWindow: 15 * time.Minute, DeleteWindow: 15 * time.Minute},
+		SMTP:       SMTP{Port: "25", Timeout: 10 * time.Second},
+		Email:      Delivery{Interval: 30 * time.Second, MaxAttempts: 5, RetryBackoff: time.Minute},
+		Webhook: Webhook{
+			Delivery: Delivery{Interval: 10 * time.Second, MaxAttempts: 8, RetryBackoff: 30 * time.Second},
+			Timeout:  10 * time.Second,
+		},
+		SSE: SSE{PollInterval: 2 * time.Second, HeartbeatInterval: 15 * time.Second, Retry: 3 * time.Second},
+		Scheduler: Scheduler{
+			DeadlineInterval:   time.Minute,
+			ReminderBefore:     24 * time.Hour,
+			EscalationInterval: 5 * time.Minute,
+		},
+	}
+}
+
+type setting struct {
+	key    string
+	usage  string
+	secret bool
+	value  flag.Value
+}
+
+func (c *Config) settings() []setting {
+	return []setting{
+		{key: "APP_PORT", usage: "HTTP port", value: intValue{&c.App.Port}},
+		{key: "WORKFLOW_FILE", usage: "workflow definition JSON file", value: stringValue{&c.App.WorkflowFile}},
+		{key: "ESCALATION_FILE", usage: "escalation rules JSON file", value: stringValue{&c.App.EscalationFile}},
+		{key: "HTTP_READ_HEADER_TIMEOUT", usage: "time allowed to read request headers", value: durationValue{&c.HTTP.ReadHeaderTimeout}},
+		{key: "HTTP_IDLE_TIMEOUT", usage: "keep-alive idle timeout", value: durationValue{&c.HTTP.IdleTimeout}},
+		{key: "SHUTDOWN_TIMEOUT", usage: "time allowed to drain requests and jobs on shutdown", value: durationValue{&c.HTTP.ShutdownTimeout}},
+		{key: "READINESS_TIMEOUT", usage: "timeout for readiness checks", value: durationValue{&c.HTTP.ReadinessTimeout}},
+		{key: "LOG_LEVEL", usage: "minimum log level (debug, info, warn, error)", value: stringValue{&c.Log.Level}},
+		{key: "LOG_FORMAT", usage: "log output format (json or text)", value: stringValue{&c.Log.Format}},
+		{key: "DB_DRIVER", usage: "mysql, postgres or sqlite", value: stringValue{&c.Database.Driver}},
+		{key: "DB_HOST", usage: "database host", value: stringValue{&c.Database.Host}},
+		{key: "DB_PORT", usage: "database port", value: stringValue{&c.Database.Port}},
+		{key: "DB_USER", usage: "database user", value: stringValue{&c.Database.User}},
+		{key: "DB_PASSWORD", usage: "database password", secret: true, value: stringValue{&c.Database.Password}},
+		{key: "DB_NAME", usage: "database name (file path for sqlite)", value: stringValue{&c.Database.Name}},
+		{key: "DB_SSLMODE", usage: "postgres sslmode", value: stringValue{&c.Database.SSLMode}},
+		{key: "DB_MAX_OPEN_CONNS", usage: "maximum open connections, 0 for unlimited", value: intValue{&c.Database.MaxOpenConns}},
+		{key: "DB_MAX_IDLE_CONNS", usage: "maximum idle connections", value: intValue{&c.Database.MaxIdleConns}},
+		{key: "DB_CONN_MAX_LIFETIME", usage: "maximum connection lifetime, 0 for unlimited", value: durationValue{&c.Database.ConnMaxLifetime}},
+		{key: "JWT_SECRET", usage: "secret used to sign access tokens", secret: true, value: stringValue{&c.Auth.JWTSecret}},
+		{key: "ACCESS_TOKEN_TTL", usage: "access token lifetime", value: durationValue{&c.Auth.AccessTokenTTL}},
+		{key: "REFRESH_TOKEN_TTL", usage: "refresh token lifetime", value: durationValue{&c.Auth.RefreshTokenTTL}},
+		{key: "PASSWORD_RESET_TTL", usage: "password reset token lifetime", value: durationValue{&c.Auth.PasswordResetTTL}},
+		{key: "PASSWORD_MIN_LENGTH", usage: "minimum password length", value: intValue{&c.Password.MinLength}},
+		{key: "PASSWORD_REQUIRE_UPPER", usage: "require an uppercase letter", value: boolValue{&c.Password.RequireUpper}},
+		{key: "PASSWORD_REQUIRE_LOWER", usage: "require a lowercase letter", value: boolValue{&c.Password.RequireLower}},
+		{key: "PASSWORD_REQUIRE_DIGIT", usage: "require a digit", value: boolValue{&c.Password.RequireDigit}},
+		{key: "PASSWORD_REQUIRE_SYMBOL", usage: "require a symbol", value: boolValue{&c.Password.RequireSymbol}},
+		{key: "CORS_ALLOW_ORIGINS", usage: "comma separated allowed origins, * for any", value: listValue{&c.CORS.AllowOrigins}},
+		{key: "STORAGE_DRIVER", usage: "local or s3", value: stringValue{&c.Storage.Driver}},
+		{key: "STORAGE_LOCAL_PATH", usage: "attachment directory for the local driver", value: stringValue{&c.Storage.LocalPath}},
+		{key: "S3_ENDPOINT", usage: "S3 endpoint URL", value: stringValue{&c.Storage.S3Endpoint}},
+		{key: "S3_REGION", usage: "S3 region", value: stringValue{&c.Storage.S3Region}},
+		{key: "S3_BUCKET", usage: "S3 bucket", value: stringValue{&c.Storage.S3Bucket}},
+		{key: "S3_ACCESS_KEY", usage: "S3 access key", value: stringValue{&c.Storage.S3AccessKey}},
+		{key: "S3_SECRET_KEY", usage: "S3 secret key", secret: true, value: stringValue{&c.Storage.S3SecretKey}},
+		{key: "ATTACHMENT_MAX_SIZE", usage: "maximum attachment size in bytes", value: int64Value{&c.Attachment.MaxSize}},
+		{key: "ATTACHMENT_ALLOWED_TYPES", usage: "comma separated allowed MIME types", value: listValue{&c.Attachment.AllowedTypes}},
+		{key: "COMMENT_EDIT_WINDOW", usage: "how long a comment can be edited", value: durationValue{&c.Comment.EditWindow}},
+		{key: "COMMENT_DELETE_WINDOW", usage: "how long a comment can be deleted", value: durationValue{&c.Comment.DeleteWindow}},
+		{key: "SMTP_HOST", usage: "SMTP host, empty disables email", value: stringValue{&c.SMTP.Host}},
+		{key: "SMTP_PORT", usage: "SMTP port", value: stringValue{&c.SMTP.Port}},
+		{key: "SMTP_FROM", usage: "sender address", value: stringValue{&c.SMTP.From}},
+		{key: "SMTP_USERNAME", usage: "SMTP username", value: stringValue{&c.SMTP.Username}},
+		{key: "SMTP_PASSWORD", usage: "SMTP password", secret: true, value: stringValue{&c.SMTP.Password}},
+		{key: "SMTP_TIMEOUT", usage: "SMTP connection timeout", value: durationValue{&c.SMTP.Timeout}},
+		{key: "EMAIL_SEND_INTERVAL", usage: "email outbox poll interval", value: durationValue{&c.Email.Interval}},
+		{key: "EMAIL_MAX_ATTEMPTS", usage: "email delivery attempts", value: intValue{&c.Email.MaxAttempts}},
+		{key: "EMAIL_RETRY_BACKOFF", usage: "initial email retry delay", value: durationValue{&c.Email.RetryBackoff}},
+		{key: "WEBHOOK_SEND_INTERVAL", usage: "webhook delivery poll interval", value: durationValue{&c.Webhook.Interval}},
+		{key: "WEBHOOK_MAX_ATTEMPTS", usage: "webhook delivery attempts", value: intValue{&c.Webhook.MaxAttempts}},
+		{key: "WEBHOOK_RETRY_BACKOFF", usage: "initial webhook retry delay", value: durationValue{&c.Webhook.RetryBackoff}},
+		{key: "WEBHOOK_TIMEOUT", usage: "webhook request timeout", value: durationValue{&c.Webhook.Timeout}},
+		{key: "SSE_POLL_INTERVAL", usage: "event stream poll interval", value: durationValue{&c.SSE.PollInterval}},
+		{key: "SSE_HEARTBEAT_INTERVAL", usage: "event stream heartbeat interval", value: durationValue{&c.SSE.HeartbeatInterval}},
+		{key: "SSE_RETRY", usage: "reconnect delay suggested to clients", value: durationValue{&c.SSE.Retry}},
+		{key: "DISABLE_SCHEDULER", usage: "do not run background jobs on this instance", value: boolValue{&c.Scheduler.Disabled}},
+		{key: "DEADLINE_CHECK_INTERVAL", usage: "deadline check interval", value: durationValue{&c.Scheduler.DeadlineInterval}},
+		{key: "DEADLINE_REMINDER_BEFORE", usage: "send reminders this long before the deadline", value: durationValue{&c.Scheduler.ReminderBefore}},
+		{key: "ESCALATION_CHECK_INTERVAL", usage: "escalation check interval", value: durationValue{&c.Scheduler.EscalationInterval}},
+	}
+}
+
+func flagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
+
+type rawFlag struct {
+	key    string
+	values map[string]string
+	bool   bool
+}
+
+func (f rawFlag) Set(s string) error { f.values[f.key] = s; return nil }
+func (f rawFlag) String() string     { return "" }
+func (f rawFlag) IsBoolFlag() bool   { return f.bool }
+
+func Load(args []string) (*Config, []string, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("load .env: %w", err)
+	}
+
+	cfg := Default()
+	settings := cfg.settings()
+
+	flagValues := map[string]string{}
+	fs := flag.NewFlagSet("task_todo", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "optional KEY=VALUE configuration file")
+	for _, s := range settings {
+		_, isBool := s.value.(boolValue)
+		fs.Var(rawFlag{key: s.key, values: flagValues, bool: isBool}, flagName(s.key), s.usage+" ("+s.key+")")
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	fileValues := map[string]string{}
+	if *configFile != "" {
+		values, err := godotenv.Read(*configFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read config file: %w", err)
+		}
+		fileValues = values
+	}
+
+	var errs []error
+	for _, s := range settings {
+		source, value := "", ""
+		if v, ok := fileValues[s.key]; ok && v != "" {
+			source, value = *configFile, v
+		}
+		if v := os.Getenv(s.key); v != "" {
+			source, value = "environment", v
+		}
+		if v, ok := flagValues[s.key]; ok {
+			source, value = "flag -"+flagName(s.key), v
+		}
+		if source == "" {
+			continue
+		}
+		if err := s.value.Set(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid value %q from %s", s.key, value, source))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	cfg.applyDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+func (c *Config) applyDefaults() {
+	if c.Database.Port == "" {
+		switch c.Database.Driver {
+		case "mysql":
+			c.Database.Port = "3306"
+		case "postgres":
+			c.Database.Port = "5432"
+		}
+	}
+	if c.Database.Driver == "sqlite" && c.Database.Name == "" {
+		c.Database.Name = "task_todo.db"
+	}
+	if c.Storage.Driver == "s3" && c.Storage.S3Region == "" {
+		c.Storage.S3Region = "us-east-1"
+	}
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.App.Port < 1 || c.App.Port > 65535 {
+		fail("APP_PORT must be between 1 and 65535")
+	}
+
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		fail("LOG_LEVEL must be one of debug, info, warn, error")
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		fail("LOG_FORMAT must be json or text")
+	}
+
+	switch c.Database.Driver {
+	case "mysql", "postgres":
+		if c.Database.Host == "" {
+			fail("DB_HOST is required for the %s driver", c.Database.Driver)
+		}
+		if c.Database.Name == "" {
+			fail("DB_NAME is required for the %s driver", c.Database.Driver)
+		}
+	case "sqlite":
+	default:
+		fail("DB_DRIVER must be mysql, postgres or sqlite")
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 || c.Database.ConnMaxLifetime < 0 {
+		fail("DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS and DB_CONN_MAX_LIFETIME cannot be negative")
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		fail("DB_MAX_IDLE_CONNS cannot be greater than DB_MAX_OPEN_CONNS")
+	}
+
+	if c.Auth.JWTSecret == "" {
+		fail("JWT_SECRET is required")
+	}
+	if c.Password.MinLength < 1 {
+		fail("PASSWORD_MIN_LENGTH must be at least 1")
+	}
+
+	for _, origin := range c.CORS.AllowOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fail("CORS_ALLOW_ORIGINS contains invalid origin %q", origin)
+		}
+	}
+	if len(c.CORS.AllowOrigins) == 0 {
+		fail("CORS_ALLOW_ORIGINS cannot be empty")
+	}
+
+	switch c.Storage.Driver {
+	case "local":
+		if c.Storage.LocalPath == "" {
+			fail("STORAGE_LOCAL_PATH is required for the local storage driver")
+		}
+	case "s3":
+		if c.Storage.S3Endpoint == "" || c.Storage.S3Bucket == "" {
+			fail("S3_ENDPOINT and S3_BUCKET are required for the s3 storage driver")
+		}
+		if c.Storage.S3AccessKey == "" || c.Storage.S3SecretKey == "" {
+			fail("S3_ACCESS_KEY and S3_SECRET_KEY are required for the s3 storage driver")
+		}
+	default:
+		fail("STORAGE_DRIVER must be local or s3")
+	}
+	if c.Attachment.MaxSize <= 0 {
+		fail("ATTACHMENT_MAX_SIZE must be positive")
+	}
+
+	if c.SMTP.Host != "" && c.SMTP.From == "" {
+		fail("SMTP_FROM is required when SMTP_HOST is set")
+	}
+
+	if c.Email.MaxAttempts < 1 {
+		fail("EMAIL_MAX_ATTEMPTS must be at least 1")
+	}
+	if c.Webhook.MaxAttempts < 1 {
+		fail("WEBHOOK_MAX_ATTEMPTS must be at least 1")
+	}
+
+	for _, d := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"HTTP_READ_HEADER_TIMEOUT", c.HTTP.ReadHeaderTimeout},
+		{"HTTP_IDLE_TIMEOUT", c.HTTP.IdleTimeout},
+		{"SHUTDOWN_TIMEOUT", c.HTTP.ShutdownTimeout},
+		{"READINESS_TIMEOUT", c.HTTP.ReadinessTimeout},
+		{"ACCESS_TOKEN_TTL", c.Auth.AccessTokenTTL},
+		{"REFRESH_TOKEN_TTL", c.Auth.RefreshTokenTTL},
+		{"PASSWORD_RESET_TTL", c.Auth.PasswordResetTTL},
+		{"COMMENT_EDIT_WINDOW", c.Comment.EditWindow},
+		{"COMMENT_DELETE_WINDOW", c.Comment.DeleteWindow},
+		{"SMTP_TIMEOUT", c.SMTP.Timeout},
+		{"EMAIL_SEND_INTERVAL", c.Email.Interval},
+		{"EMAIL_RETRY_BACKOFF", c.Email.RetryBackoff},
+		{"WEBHOOK_SEND_INTERVAL", c.Webhook.Interval},
+		{"WEBHOOK_RETRY_BACKOFF", c.Webhook.RetryBackoff},
+		{"WEBHOOK_TIMEOUT", c.Webhook.Timeout},
+		{"SSE_POLL_INTERVAL", c.SSE.PollInterval},
+		{"SSE_HEARTBEAT_INTERVAL", c.SSE.HeartbeatInterval},
+		{"SSE_RETRY", c.SSE.Retry},
+		{"DEADLINE_CHECK_INTERVAL", c.Scheduler.DeadlineInterval},
+		{"DEADLINE_REMINDER_BEFORE", c.Scheduler.ReminderBefore},
+		{"ESCALATION_CHECK_INTERVAL", c.Scheduler.EscalationInterval},
+	} {
+		if d.value <= 0 {
+			fail("%s must be a positive duration", d.key)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Config) Redacted() []string {
+	var lines []string
+	for _, s := range c.settings() {
+		value := s.value.String()
+		if s.secret && value != "" {
+			value = "********"
+		}
+		lines = append(lines, s.key+"="+value)
+	}
+	return lines
+}
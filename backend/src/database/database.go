@@ -1,40 +1,100 @@
 package database
 
 import (
-	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/ardhia137/task_todo/src/config"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 var DB *gorm.DB
 
 func Connect() {
-	var err error
+	cfg := config.Current.Database
 
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbName := os.Getenv("DB_NAME")
+	dialector, err := Dialector(cfg)
+	if err != nil {
+		slog.Error("failed to configure database", "error", err)
+		os.Exit(1)
+	}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		dbUser, dbPassword, dbHost, dbPort, dbName)
+	DB, err = gorm.Open(dialector, &gorm.Config{
+		Logger: logger.NewSlogLogger(slog.Default(), logger.Config{
+			SlowThreshold:             200 * time.Millisecond,
+			LogLevel:                  logger.Warn,
+			IgnoreRecordNotFoundError: true,
+		}),
+	})
+	if err != nil {
+		slog.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
 
-	sqlDB, err := sql.Open("mysql", dsn)
+	sqlDB, err := DB.DB()
 	if err != nil {
-		log.Fatalf("Failed to open database: %v", err)
+		slog.Error("failed to configure database pool", "error", err)
+		os.Exit(1)
 	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	slog.Info("database connection established", "driver", DB.Dialector.Name())
+}
 
-	DB, err = gorm.Open(mysql.New(mysql.Config{
-		Conn: sqlDB,
-	}), &gorm.Config{})
+func Dialector(cfg config.Database) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case "mysql":
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+			cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name)
+		return mysql.Open(dsn), nil
+	case "postgres":
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(cfg.User, cfg.Password),
+			Host:     net.JoinHostPort(cfg.Host, cfg.Port),
+			Path:     "/" + cfg.Name,
+			RawQuery: url.Values{"sslmode": {cfg.SSLMode}}.Encode(),
+		}
+		return postgres.Open(dsn.String()), nil
+	case "sqlite":
+		dsn, err := sqliteDSN(cfg.Name)
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER '%s' (use mysql, postgres or sqlite)", cfg.Driver)
+	}
+}
+
+func sqliteDSN(name string) (string, error) {
+	path, rawQuery, _ := strings.Cut(name, "?")
+	params, err := url.ParseQuery(rawQuery)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		return "", fmt.Errorf("invalid sqlite DB_NAME '%s': %w", name, err)
 	}
 
-	log.Println("Database connection established")
+	defaults := url.Values{
+		"_foreign_keys": {"on"},
+		"_busy_timeout": {"5000"},
+		"_journal_mode": {"WAL"},
+		"_txlock":       {"immediate"},
+	}
+	for key, value := range defaults {
+		if !params.Has(key) {
+			params[key] = value
+		}
+	}
+	return path + "?" + params.Encode(), nil
 }
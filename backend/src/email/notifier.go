@@ -0,0 +1,65 @@
+package email
+
+import (
+	"context"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/notify"
+	"gorm.io/gorm"
+)
+
+type Notifier struct {
+	DB *gorm.DB
+}
+
+func (n Notifier) Notify(ctx context.Context, event notify.Event) error {
+	db := n.DB.WithContext(ctx)
+
+	ids := event.Audience()
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var recipients []model.User
+	if err := db.Where("id IN ? AND active = ? AND email <> ''", ids, true).Find(&recipients).Error; err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	var actor string
+	if event.ActorID != nil {
+		var user model.User
+		if err := db.Select("username").First(&user, *event.ActorID).Error; err == nil {
+			actor = user.Username
+		}
+	}
+
+	var messages []model.EmailOutbox
+	for _, recipient := range recipients {
+		subject, body, err := Render(TemplateData{
+			Type:       event.Type,
+			Recipient:  recipient,
+			Actor:      actor,
+			Task:       event.Task,
+			Note:       event.Note,
+			OccurredAt: event.OccurredAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		taskID := event.Task.ID
+		messages = append(messages, model.EmailOutbox{
+			Recipient:     recipient.Email,
+			Subject:       subject,
+			Body:          body,
+			EventType:     event.Type,
+			TaskID:        &taskID,
+			NextAttemptAt: event.OccurredAt,
+		})
+	}
+
+	return db.Create(&messages).Error
+}
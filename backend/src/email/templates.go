@@ -0,0 +1,58 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+type TemplateData struct {
+	Type       string
+	Recipient  model.User
+	Actor      string
+	Task       model.Task
+	Note       string
+	OccurredAt time.Time
+}
+
+var templates = map[string]*template.Template{}
+
+func init() {
+	entries, err := templateFiles.ReadDir("templates")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".tmpl")
+		if name == "base" {
+			continue
+		}
+		templates[name] = template.Must(template.ParseFS(templateFiles, "templates/base.tmpl", "templates/"+entry.Name()))
+	}
+}
+
+func Render(data TemplateData) (string, string, error) {
+	tmpl, ok := templates[data.Type]
+	if !ok {
+		tmpl = templates["default"]
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", fmt.Errorf("render subject: %w", err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", fmt.Errorf("render body: %w", err)
+	}
+
+	return strings.TrimSpace(subject.String()), strings.TrimLeft(body.String(), "\n"), nil
+}
@@ -0,0 +1,187 @@
+package escalation
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/workflow"
+)
+
+//go:embed default_escalation.json
+var defaultConfig []byte
+
+var ErrInvalidConfig = errors.New("invalid escalation config")
+
+type Calendar struct {
+	Start string         `json:"start"`
+	End   string         `json:"end"`
+	Days  []time.Weekday `json:"days"`
+
+	startOffset time.Duration
+	endOffset   time.Duration
+}
+
+type Rule struct {
+	Name          string   `json:"name"`
+	Status        string   `json:"status"`
+	After         string   `json:"after"`
+	WorkingHours  bool     `json:"working_hours"`
+	NotifyRoles   []string `json:"notify_roles"`
+	AllowReassign bool     `json:"allow_reassign"`
+
+	threshold time.Duration
+}
+
+type Config struct {
+	Calendar Calendar `json:"calendar"`
+	Rules    []Rule   `json:"rules"`
+}
+
+var Current *Config
+
+func Load(path string) error {
+	data := defaultConfig
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read escalation file: %w", err)
+		}
+	}
+
+	config, err := Parse(data)
+	if err != nil {
+		return err
+	}
+
+	Current = config
+	return nil
+}
+
+func Parse(data []byte) (*Config, error) {
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parse escalation config: %w", err)
+	}
+	if err := config.prepare(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (c *Config) prepare() error {
+	var err error
+	if c.Calendar.startOffset, err = parseClock(c.Calendar.Start); err != nil {
+		return fmt.Errorf("%w: calendar start: %v", ErrInvalidConfig, err)
+	}
+	if c.Calendar.endOffset, err = parseClock(c.Calendar.End); err != nil {
+		return fmt.Errorf("%w: calendar end: %v", ErrInvalidConfig, err)
+	}
+	if c.Calendar.endOffset <= c.Calendar.startOffset {
+		return fmt.Errorf("%w: calendar end must be after start", ErrInvalidConfig)
+	}
+
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.Name == "" || rule.Status == "" {
+			return fmt.Errorf("%w: rule %d needs a name and a status", ErrInvalidConfig, i)
+		}
+		if rule.threshold, err = time.ParseDuration(rule.After); err != nil || rule.threshold <= 0 {
+			return fmt.Errorf("%w: rule '%s' has invalid duration '%s'", ErrInvalidConfig, rule.Name, rule.After)
+		}
+	}
+	return nil
+}
+
+func (c *Config) Validate(def *workflow.Definition) error {
+	open := def.OpenStates()
+	for _, rule := range c.Rules {
+		if !slices.Contains(open, rule.Status) {
+			return fmt.Errorf("%w: rule '%s' uses status '%s' which is not an open workflow state", ErrInvalidConfig, rule.Name, rule.Status)
+		}
+		for _, role := range rule.NotifyRoles {
+			if !slices.Contains(workflow.Roles, role) {
+				return fmt.Errorf("%w: rule '%s' notifies unknown role '%s'", ErrInvalidConfig, rule.Name, role)
+			}
+		}
+	}
+	return nil
+}
+
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (c *Config) RulesFor(status string) []Rule {
+	var rules []Rule
+	for _, rule := range c.Rules {
+		if rule.Status == status {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+func (c *Config) Statuses() []string {
+	var statuses []string
+	for _, rule := range c.Rules {
+		if !slices.Contains(statuses, rule.Status) {
+			statuses = append(statuses, rule.Status)
+		}
+	}
+	return statuses
+}
+
+func (c *Config) Find(name string) (Rule, bool) {
+	for _, rule := range c.Rules {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+func (c *Config) Due(rule Rule, since, now time.Time) bool {
+	elapsed := now.Sub(since)
+	if rule.WorkingHours {
+		elapsed = c.Calendar.WorkingDuration(since, now)
+	}
+	return elapsed >= rule.threshold
+}
+
+func (cal Calendar) WorkingDuration(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+
+	from, to = from.In(time.Local), to.In(time.Local)
+
+	var total time.Duration
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for !day.After(to) {
+		if slices.Contains(cal.Days, day.Weekday()) {
+			start := day.Add(cal.startOffset)
+			end := day.Add(cal.endOffset)
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return total
+}
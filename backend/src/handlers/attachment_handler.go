@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ardhia137/task_todo/src/config"
+	"github.com/ardhia137/task_todo/src/logging"
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/notify"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/storage"
+	"github.com/ardhia137/task_todo/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+var defaultAttachmentTypes = []string{
+	"application/pdf",
+	"application/zip",
+	"image/gif",
+	"image/jpeg",
+	"image/png",
+	"text/plain",
+}
+
+func attachmentMaxSize() int64 {
+	return config.Current.Attachment.MaxSize
+}
+
+func attachmentAllowedTypes() []string {
+	configured := config.Current.Attachment.AllowedTypes
+	if len(configured) == 0 {
+		return defaultAttachmentTypes
+	}
+	return configured
+}
+
+func loadAttachment(c *gin.Context, task model.Task) (model.TaskAttachment, bool) {
+	attachmentID, ok := idParam(c, "attachmentId", "Invalid attachment ID")
+	if !ok {
+		return model.TaskAttachment{}, false
+	}
+
+	attachment, err := repository.Default.Attachments.Find(c.Request.Context(), task.ID, attachmentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Attachment not found"})
+		} else {
+			respondInternalError(c, "Failed to load attachment", err)
+		}
+		return model.TaskAttachment{}, false
+	}
+
+	return attachment, true
+}
+
+func GetTaskAttachments(c *gin.Context) {
+	task, _, ok := loadTaskFor(c, policy.ActionView)
+	if !ok {
+		return
+	}
+
+	attachments, err := repository.Default.Attachments.ListByTask(c.Request.Context(), task.ID)
+	if err != nil {
+		respondInternalError(c, "Failed to retrieve attachments", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"attachments": attachments})
+}
+
+func UploadTaskAttachment(c *gin.Context) {
+	task, actor, ok := loadTaskFor(c, policy.ActionView)
+	if !ok {
+		return
+	}
+
+	maxSize := attachmentMaxSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("File exceeds the maximum size of %d bytes", maxSize)})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return
+	}
+
+	if fileHeader.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("File exceeds the maximum size of %d bytes", maxSize)})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if !slices.Contains(attachmentAllowedTypes(), mediaType) {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": fmt.Sprintf("File type '%s' is not allowed", mediaType)})
+		return
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		respondInternalError(c, "Failed to read uploaded file", err)
+		return
+	}
+
+	token, err := utils.RandomToken(16)
+	if err != nil {
+		respondInternalError(c, "Failed to store attachment", err)
+		return
+	}
+
+	attachment := model.TaskAttachment{
+		TaskID:      task.ID,
+		UploadedBy:  actor.ID,
+		FileName:    normalizeFileName(fileHeader.Filename),
+		ContentType: contentType,
+		Size:        fileHeader.Size,
+		StorageKey:  fmt.Sprintf("tasks/%d/%s", task.ID, token),
+	}
+
+	if err := storage.Default.Put(c.Request.Context(), attachment.StorageKey, file, fileHeader.Size, contentType); err != nil {
+		respondInternalError(c, "Failed to store attachment", err)
+		return
+	}
+
+	if err := repository.Default.Attachments.Create(c.Request.Context(), &attachment); err != nil {
+		if err := storage.Default.Delete(c.Request.Context(), attachment.StorageKey); err != nil {
+			logging.FromContext(c.Request.Context()).Warn("failed to remove orphaned attachment", "key", attachment.StorageKey, "error", err)
+		}
+		respondInternalError(c, "Failed to save attachment", err)
+		return
+	}
+
+	attachment, err = repository.Default.Attachments.Find(c.Request.Context(), task.ID, attachment.ID)
+	if err != nil {
+		respondInternalError(c, "Failed to load attachment", err)
+		return
+	}
+
+	notify.Publish(c.Request.Context(), notify.Event{
+		Type:       "attachment_uploaded",
+		Task:       task,
+		ActorID:    &actor.ID,
+		Note:       attachment.FileName,
+		OccurredAt: attachment.CreatedAt,
+	})
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "Attachment uploaded successfully",
+		"attachment": attachment,
+	})
+}
+
+func DownloadTaskAttachment(c *gin.Context) {
+	task, _, ok := loadTaskFor(c, policy.ActionView)
+	if !ok {
+		return
+	}
+
+	attachment, ok := loadAttachment(c, task)
+	if !ok {
+		return
+	}
+
+	body, err := storage.Default.Get(c.Request.Context(), attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Attachment file not found"})
+		} else {
+			respondInternalError(c, "Failed to read attachment", err)
+		}
+		return
+	}
+	defer body.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, body, map[string]string{
+		"Content-Disposition":    disposition,
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func DeleteTaskAttachment(c *gin.Context) {
+	task, actor, ok := loadTaskFor(c, policy.ActionView)
+	if !ok {
+		return
+	}
+
+	attachment, ok := loadAttachment(c, task)
+	if !ok {
+		return
+	}
+
+	if attachment.UploadedBy != actor.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own attachments"})
+		return
+	}
+
+	if err := repository.Default.Attachments.Delete(c.Request.Context(), attachment); err != nil {
+		respondInternalError(c, "Failed to delete attachment", err)
+		return
+	}
+
+	if err := storage.Default.Delete(c.Request.Context(), attachment.StorageKey); err != nil {
+		logging.FromContext(c.Request.Context()).Warn("failed to remove attachment from storage", "key", attachment.StorageKey, "error", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Attachment deleted successfully",
+	})
+}
+
+func removeTaskAttachments(c *gin.Context, keys []string) {
+	for _, key := range keys {
+		if err := storage.Default.Delete(c.Request.Context(), key); err != nil {
+			logging.FromContext(c.Request.Context()).Warn("failed to remove attachment from storage", "key", key, "error", err)
+		}
+	}
+}
+
+func normalizeFileName(name string) string {
+	return strings.TrimSpace(filepath.Base(name))
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/ardhia137/task_todo/src/config"
+	"github.com/ardhia137/task_todo/src/storage"
+)
+
+const attachmentsRoute = "/tasks/:id/attachments"
+
+type memoryStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *memoryStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryStorage) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	return nil
+}
+
+func withAttachmentConfig(t *testing.T, maxSize int64, allowed ...string) *memoryStorage {
+	t.Helper()
+
+	previousConfig, previousStorage := config.Current, storage.Default
+	t.Cleanup(func() { config.Current, storage.Default = previousConfig, previousStorage })
+
+	cfg := *config.Current
+	cfg.Attachment = config.Attachment{MaxSize: maxSize, AllowedTypes: allowed}
+	config.Current = &cfg
+
+	store := &memoryStorage{objects: map[string][]byte{}}
+	storage.Default = store
+	return store
+}
+
+func upload(t *testing.T, name string, content []byte) (*bytes.Buffer, string) {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := form.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &body, form.FormDataContentType()
+}
+
+func postUpload(t *testing.T, name string, content []byte) (int, string) {
+	t.Helper()
+
+	body, contentType := upload(t, name, content)
+	w := perform(UploadTaskAttachment, pelaksana, http.MethodPost, attachmentsRoute, "/tasks/1/attachments", body, contentType)
+	return w.Code, w.Body.String()
+}
+
+func TestUploadTaskAttachmentRejectsOversizedFiles(t *testing.T) {
+	repos := newTestRepositories(t)
+	store := withAttachmentConfig(t, 64)
+
+	code, body := postUpload(t, "notes.txt", bytes.Repeat([]byte("a"), 65))
+	if code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413 (body %s)", code, body)
+	}
+
+	code, body = postUpload(t, "notes.txt", bytes.Repeat([]byte("a"), 2<<20))
+	if code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413 for a body past the request limit (body %s)", code, body)
+	}
+
+	if attachments, _ := repos.Attachments.ListByTask(context.Background(), 1); len(attachments) != 0 || len(store.objects) != 0 {
+		t.Fatalf("oversized uploads were stored: %d rows, %d objects", len(attachments), len(store.objects))
+	}
+}
+
+func TestUploadTaskAttachmentRejectsDisallowedTypes(t *testing.T) {
+	repos := newTestRepositories(t)
+	store := withAttachmentConfig(t, 1024)
+
+	for name, content := range map[string][]byte{
+		"page.html":  []byte("<html><body>hi</body></html>"),
+		"binary.exe": {0x7f, 'E', 'L', 'F', 0x02, 0x01, 0x01, 0x00, 0x00},
+		"report.pdf": []byte("<!DOCTYPE html><p>not a pdf</p>"),
+	} {
+		code, body := postUpload(t, name, content)
+		if code != http.StatusUnsupportedMediaType {
+			t.Errorf("%s: status = %d, want 415 (body %s)", name, code, body)
+		}
+	}
+
+	if attachments, _ := repos.Attachments.ListByTask(context.Background(), 1); len(attachments) != 0 || len(store.objects) != 0 {
+		t.Fatalf("disallowed uploads were stored: %d rows, %d objects", len(attachments), len(store.objects))
+	}
+}
+
+func TestUploadTaskAttachmentHonoursConfiguredTypes(t *testing.T) {
+	repos := newTestRepositories(t)
+	store := withAttachmentConfig(t, 1024, "application/pdf")
+
+	code, body := postUpload(t, "notes.txt", []byte("plain text"))
+	if code != http.StatusUnsupportedMediaType {
+		t.Fatalf("status = %d, want 415 for a type outside the configured list (body %s)", code, body)
+	}
+
+	code, body = postUpload(t, "../../spec.pdf", []byte("%PDF-1.4\n%âãÏÓ\n"))
+	if code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201 (body %s)", code, body)
+	}
+
+	attachments, err := repos.Attachments.ListByTask(context.Background(), 1)
+	if err != nil || len(attachments) != 1 {
+		t.Fatalf("attachments = %+v, %v, want one", attachments, err)
+	}
+	if attachments[0].FileName != "spec.pdf" || attachments[0].Uploader.ID != pelaksanaID {
+		t.Fatalf("attachment = %+v, want spec.pdf uploaded by the pelaksana", attachments[0])
+	}
+	if _, ok := store.objects[attachments[0].StorageKey]; !ok {
+		t.Fatalf("object %s was not stored", attachments[0].StorageKey)
+	}
+	if events := published.take(); len(events) != 1 || events[0].Type != "attachment_uploaded" {
+		t.Fatalf("events = %+v, want one attachment_uploaded", events)
+	}
+}
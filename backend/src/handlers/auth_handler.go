@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
-	"github.com/ardhia137/task_todo/src/database"
+	"github.com/ardhia137/task_todo/src/auth"
 	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/repository"
 	"github.com/ardhia137/task_todo/src/utils"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
@@ -17,9 +20,13 @@ func LoginHandler(c *gin.Context) {
 		return
 	}
 
-	var user model.User
-	if err := database.DB.Where("username = ?", req.Username).First(&user).Error; err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+	user, err := repository.Default.Users.FindByUsername(c.Request.Context(), req.Username)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+		} else {
+			respondInternalError(c, "Failed to load user", err)
+		}
 		return
 	}
 
@@ -28,14 +35,217 @@ func LoginHandler(c *gin.Context) {
 		return
 	}
 
-	token, err := utils.GenerateJWT(user.ID, user.Username, user.Role)
+	if !user.Active {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Account is deactivated"})
+		return
+	}
+
+	tokens, err := auth.IssueSession(c.Request.Context(), repository.Default.Sessions, user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		respondInternalError(c, "Failed to generate token", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, model.LoginResponse{
-		Token: token,
-		Role:  user.Role,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		Role:         tokens.Role,
 	})
 }
+
+func RefreshTokenHandler(c *gin.Context) {
+	var req model.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	tokens, err := auth.Refresh(c.Request.Context(), repository.Default.Sessions, req.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidRefreshToken),
+			errors.Is(err, auth.ErrRefreshTokenReused),
+			errors.Is(err, auth.ErrSessionRevoked),
+			errors.Is(err, auth.ErrUserInactive):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		default:
+			respondInternalError(c, "Failed to refresh token", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, model.LoginResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		Role:         tokens.Role,
+	})
+}
+
+func LogoutHandler(c *gin.Context) {
+	sessionID, err := utils.GetSessionIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	if err := auth.RevokeSession(c.Request.Context(), repository.Default.Sessions, sessionID); err != nil {
+		respondInternalError(c, "Failed to logout", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+func ChangePasswordHandler(c *gin.Context) {
+	var req model.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	user, err := repository.Default.Users.Find(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		} else {
+			respondInternalError(c, "Failed to load user", err)
+		}
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Current password is incorrect"})
+		return
+	}
+
+	if req.NewPassword == req.CurrentPassword {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "New password must be different from the current password"})
+		return
+	}
+
+	if err := utils.CurrentPasswordPolicy().Validate(req.NewPassword); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		respondInternalError(c, "Failed to hash password", err)
+		return
+	}
+
+	if err := repository.Default.Users.Update(c.Request.Context(), user, repository.UserChange{
+		Updates:        map[string]interface{}{"password": hash},
+		RevokeSessions: true,
+	}); err != nil {
+		respondInternalError(c, "Failed to change password", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully, please log in again"})
+}
+
+func IssuePasswordResetHandler(c *gin.Context) {
+	managerID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	user, ok := loadUser(c)
+	if !ok {
+		return
+	}
+
+	if user.ID == managerID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You cannot issue a reset token for your own account, use PUT /auth/password instead"})
+		return
+	}
+
+	if user.Role == "manager" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You cannot issue a reset token for a manager account"})
+		return
+	}
+
+	if !user.Active {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Account is deactivated"})
+		return
+	}
+
+	rawToken, err := utils.RandomToken(32)
+	if err != nil {
+		respondInternalError(c, "Failed to generate reset token", err)
+		return
+	}
+
+	resetToken := model.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(rawToken),
+		ExpiresAt: time.Now().Add(utils.PasswordResetTTL()),
+		CreatedBy: managerID,
+	}
+
+	audit, err := userAudit(managerID, "password_reset_issued", nil)
+	if err != nil {
+		respondInternalError(c, "Failed to issue reset token", err)
+		return
+	}
+
+	if err := repository.Default.PasswordResets.Issue(c.Request.Context(), &resetToken, audit); err != nil {
+		respondInternalError(c, "Failed to issue reset token", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "Password reset token issued successfully",
+		"token":      rawToken,
+		"expires_at": resetToken.ExpiresAt,
+	})
+}
+
+func ResetPasswordHandler(c *gin.Context) {
+	var req model.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := utils.CurrentPasswordPolicy().Validate(req.NewPassword); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		respondInternalError(c, "Failed to hash password", err)
+		return
+	}
+
+	resetToken, err := repository.Default.PasswordResets.FindByHash(c.Request.Context(), utils.HashToken(req.Token))
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		respondInternalError(c, "Failed to reset password", err)
+		return
+	}
+
+	now := time.Now()
+	if err != nil || resetToken.UsedAt != nil || now.After(resetToken.ExpiresAt) || !resetToken.User.Active {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired reset token"})
+		return
+	}
+
+	if err := repository.Default.PasswordResets.Redeem(c.Request.Context(), resetToken, hash, now); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired reset token"})
+		} else {
+			respondInternalError(c, "Failed to reset password", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully, please log in again"})
+}
@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/config"
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/notify"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/gin-gonic/gin"
+)
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_]+(?:[.-][A-Za-z0-9_]+)*)`)
+
+func commentEditWindow() time.Duration {
+	return config.Current.Comment.EditWindow
+}
+
+func commentDeleteWindow() time.Duration {
+	return config.Current.Comment.DeleteWindow
+}
+
+func resolveMentions(ctx context.Context, task model.Task, body string) ([]model.User, error) {
+	var usernames []string
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			usernames = append(usernames, match[1])
+		}
+	}
+
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	users, err := repository.Default.Users.ActiveByUsernames(ctx, usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	var visible []model.User
+	for _, user := range users {
+		if policy.CanTask(policy.Actor{ID: user.ID, Role: user.Role}, policy.ActionView, &task) == nil {
+			visible = append(visible, user)
+		}
+	}
+	return visible, nil
+}
+
+func loadComment(c *gin.Context, task model.Task) (model.TaskComment, bool) {
+	commentID, ok := idParam(c, "commentId", "Invalid comment ID")
+	if !ok {
+		return model.TaskComment{}, false
+	}
+
+	comment, err := repository.Default.Comments.Find(c.Request.Context(), task.ID, commentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		} else {
+			respondInternalError(c, "Failed to load comment", err)
+		}
+		return model.TaskComment{}, false
+	}
+
+	if comment.DeletedAt != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return model.TaskComment{}, false
+	}
+
+	return comment, true
+}
+
+func GetTaskComments(c *gin.Context) {
+	var query model.CommentListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, _, ok := loadTaskFor(c, policy.ActionView)
+	if !ok {
+		return
+	}
+
+	bounds := paginate(query.Page, query.PageSize, 0)
+	comments, total, err := repository.Default.Comments.Threads(c.Request.Context(), task.ID, (bounds.Page-1)*bounds.PageSize, bounds.PageSize)
+	if err != nil {
+		respondInternalError(c, "Failed to retrieve comments", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comments":   comments,
+		"pagination": paginate(bounds.Page, bounds.PageSize, total),
+	})
+}
+
+func CreateTaskComment(c *gin.Context) {
+	var req model.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, actor, ok := loadTaskFor(c, policy.ActionView)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	comment := model.TaskComment{
+		TaskID:   task.ID,
+		AuthorID: actor.ID,
+		Body:     req.Body,
+	}
+
+	if req.ParentID != nil {
+		parent, err := repository.Default.Comments.Find(ctx, task.ID, *req.ParentID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			respondInternalError(c, "Failed to load parent comment", err)
+			return
+		}
+		if err != nil || parent.DeletedAt != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parent comment not found"})
+			return
+		}
+		if parent.ParentID != nil {
+			comment.ParentID = parent.ParentID
+		} else {
+			comment.ParentID = &parent.ID
+		}
+	}
+
+	mentions, err := resolveMentions(ctx, task, req.Body)
+	if err != nil {
+		respondInternalError(c, "Failed to resolve mentions", err)
+		return
+	}
+	comment.Mentions = mentions
+
+	if err := repository.Default.Comments.Create(ctx, &comment); err != nil {
+		respondInternalError(c, "Failed to create comment", err)
+		return
+	}
+
+	comment, err = repository.Default.Comments.Find(ctx, task.ID, comment.ID)
+	if err != nil {
+		respondInternalError(c, "Failed to load comment", err)
+		return
+	}
+
+	var mentioned []uint
+	for _, user := range comment.Mentions {
+		mentioned = append(mentioned, user.ID)
+	}
+	notify.Publish(ctx, notify.Event{
+		Type:       "comment_created",
+		Task:       task,
+		ActorID:    &actor.ID,
+		Note:       comment.Body,
+		Recipients: mentioned,
+		OccurredAt: comment.CreatedAt,
+	})
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Comment created successfully",
+		"comment": comment,
+	})
+}
+
+func UpdateTaskComment(c *gin.Context) {
+	var req model.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, actor, ok := loadTaskFor(c, policy.ActionView)
+	if !ok {
+		return
+	}
+
+	comment, ok := loadComment(c, task)
+	if !ok {
+		return
+	}
+
+	if comment.AuthorID != actor.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only edit your own comments"})
+		return
+	}
+
+	if time.Since(comment.CreatedAt) > commentEditWindow() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Comment can no longer be edited"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	mentions, err := resolveMentions(ctx, task, req.Body)
+	if err != nil {
+		respondInternalError(c, "Failed to resolve mentions", err)
+		return
+	}
+
+	var added []uint
+	for _, user := range mentions {
+		if !slices.ContainsFunc(comment.Mentions, func(previous model.User) bool { return previous.ID == user.ID }) {
+			added = append(added, user.ID)
+		}
+	}
+
+	now := time.Now()
+	if err := repository.Default.Comments.Update(ctx, comment, req.Body, now, mentions); err != nil {
+		respondInternalError(c, "Failed to update comment", err)
+		return
+	}
+
+	comment, err = repository.Default.Comments.Find(ctx, task.ID, comment.ID)
+	if err != nil {
+		respondInternalError(c, "Failed to load comment", err)
+		return
+	}
+
+	if len(added) > 0 {
+		notify.Publish(ctx, notify.Event{
+			Type:       "comment_updated",
+			Task:       task,
+			ActorID:    &actor.ID,
+			Note:       comment.Body,
+			Recipients: added,
+			OccurredAt: now,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment updated successfully",
+		"comment": comment,
+	})
+}
+
+func DeleteTaskComment(c *gin.Context) {
+	task, actor, ok := loadTaskFor(c, policy.ActionView)
+	if !ok {
+		return
+	}
+
+	comment, ok := loadComment(c, task)
+	if !ok {
+		return
+	}
+
+	if comment.AuthorID != actor.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own comments"})
+		return
+	}
+
+	if time.Since(comment.CreatedAt) > commentDeleteWindow() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Comment can no longer be deleted"})
+		return
+	}
+
+	if err := repository.Default.Comments.Delete(c.Request.Context(), comment, time.Now()); err != nil {
+		respondInternalError(c, "Failed to delete comment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment deleted successfully",
+	})
+}
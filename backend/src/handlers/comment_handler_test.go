@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+const commentsRoute = "/tasks/:id/comments"
+
+func TestCreateTaskCommentRejectsDeletedParent(t *testing.T) {
+	repos := newTestRepositories(t)
+
+	parent := model.TaskComment{TaskID: 1, AuthorID: leaderID, Body: "first"}
+	if err := repos.Comments.Create(context.Background(), &parent); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.Comments.Delete(context.Background(), parent, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	w := performJSON(CreateTaskComment, pelaksana, http.MethodPost, commentsRoute, "/tasks/1/comments", map[string]any{
+		"body":      "reply",
+		"parent_id": parent.ID,
+	})
+	expectStatus(t, w, http.StatusBadRequest)
+}
+
+func TestCreateTaskCommentThreadsRepliesUnderRoot(t *testing.T) {
+	repos := newTestRepositories(t)
+
+	root := model.TaskComment{TaskID: 1, AuthorID: leaderID, Body: "root"}
+	if err := repos.Comments.Create(context.Background(), &root); err != nil {
+		t.Fatal(err)
+	}
+	reply := model.TaskComment{TaskID: 1, AuthorID: leaderID, Body: "reply", ParentID: &root.ID}
+	if err := repos.Comments.Create(context.Background(), &reply); err != nil {
+		t.Fatal(err)
+	}
+
+	w := performJSON(CreateTaskComment, pelaksana, http.MethodPost, commentsRoute, "/tasks/1/comments", map[string]any{
+		"body":      "nested @leader",
+		"parent_id": reply.ID,
+	})
+	expectStatus(t, w, http.StatusCreated)
+
+	var created struct {
+		Comment model.TaskComment `json:"comment"`
+	}
+	decode(t, w, &created)
+	if created.Comment.ParentID == nil || *created.Comment.ParentID != root.ID {
+		t.Fatalf("parent_id = %v, want %d", created.Comment.ParentID, root.ID)
+	}
+	if len(created.Comment.Mentions) != 1 || created.Comment.Mentions[0].ID != leaderID {
+		t.Fatalf("mentions = %+v, want leader", created.Comment.Mentions)
+	}
+
+	events := published.take()
+	if len(events) != 1 || events[0].Type != "comment_created" || len(events[0].Recipients) != 1 || events[0].Recipients[0] != leaderID {
+		t.Fatalf("events = %+v, want one comment_created for the leader", events)
+	}
+
+	w = perform(GetTaskComments, pelaksana, http.MethodGet, commentsRoute, "/tasks/1/comments", nil, "")
+	expectStatus(t, w, http.StatusOK)
+
+	var listed struct {
+		Comments   []model.TaskComment `json:"comments"`
+		Pagination model.Pagination    `json:"pagination"`
+	}
+	decode(t, w, &listed)
+	if listed.Pagination.Total != 1 || len(listed.Comments) != 1 || len(listed.Comments[0].Replies) != 2 {
+		t.Fatalf("threads = %+v, want one thread with two replies", listed)
+	}
+}
+
+func TestUpdateTaskCommentNotifiesNewMentionsOnly(t *testing.T) {
+	repos := newTestRepositories(t)
+
+	comment := model.TaskComment{
+		TaskID:   1,
+		AuthorID: pelaksanaID,
+		Body:     "hi @leader",
+		Mentions: []model.User{{ID: leaderID, Username: "leader", Role: "leader", Active: true}},
+	}
+	if err := repos.Comments.Create(context.Background(), &comment); err != nil {
+		t.Fatal(err)
+	}
+
+	route := commentsRoute + "/:commentId"
+	w := performJSON(UpdateTaskComment, pelaksana, http.MethodPut, route, "/tasks/1/comments/1", map[string]any{"body": "still @leader"})
+	expectStatus(t, w, http.StatusOK)
+	if events := published.take(); len(events) != 0 {
+		t.Fatalf("events = %+v, want none for an existing mention", events)
+	}
+
+	w = performJSON(UpdateTaskComment, pelaksana, http.MethodPut, route, "/tasks/1/comments/1", map[string]any{"body": "@leader @manager"})
+	expectStatus(t, w, http.StatusOK)
+	events := published.take()
+	if len(events) != 1 || events[0].Type != "comment_updated" || len(events[0].Recipients) != 1 || events[0].Recipients[0] != managerID {
+		t.Fatalf("events = %+v, want one comment_updated for the manager", events)
+	}
+
+	w = performJSON(UpdateTaskComment, leader, http.MethodPut, route, "/tasks/1/comments/1", map[string]any{"body": "hijack"})
+	expectStatus(t, w, http.StatusForbidden)
+}
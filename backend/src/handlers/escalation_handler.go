@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/service"
+	"github.com/gin-gonic/gin"
+)
+
+func ReassignTask(c *gin.Context) {
+	var req model.ReassignRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, ok := taskIDParam(c)
+	if !ok {
+		return
+	}
+
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	updatedTask, err := service.Default.Reassign(c.Request.Context(), service.ReassignInput{
+		TaskID:   taskID,
+		Actor:    actor,
+		LeaderID: req.LeaderID,
+		Note:     req.Note,
+		Precondition: func(task model.Task) error {
+			return checkIfMatch(c, task)
+		},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, policy.ErrTaskNotFound), errors.Is(err, policy.ErrForbidden):
+			respondPolicyError(c, err)
+		case errors.Is(err, service.ErrVersionMismatch):
+			respondVersionMismatch(c, updatedTask)
+		case errors.Is(err, service.ErrNotEscalated):
+			c.JSON(http.StatusConflict, gin.H{"error": "Only escalated tasks can be reassigned"})
+		case errors.Is(err, service.ErrReassignNotAllowed):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Escalation rule does not allow reassignment"})
+		case errors.Is(err, service.ErrSameLeader):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Task is already assigned to this leader"})
+		case errors.Is(err, service.ErrLeaderNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Leader not found or inactive"})
+		default:
+			respondInternalError(c, "Failed to reassign task", err)
+		}
+		return
+	}
+
+	c.Header("ETag", taskETag(updatedTask))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Task reassigned successfully",
+		"task":    updatedTask,
+	})
+}
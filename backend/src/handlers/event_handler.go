@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/auth"
+	"github.com/ardhia137/task_todo/src/config"
+	"github.com/ardhia137/task_todo/src/logging"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/stream"
+	"github.com/ardhia137/task_todo/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func StreamEvents(c *gin.Context) {
+	if stream.Default == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Event stream is not available"})
+		return
+	}
+
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	sessionID, err := utils.GetSessionIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var after uint64
+	if lastEventID != "" {
+		if after, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	sub := stream.Default.Subscribe(actor)
+	defer stream.Default.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", config.Current.SSE.Retry.Milliseconds())
+
+	replayed := map[uint]bool{}
+	if after > 0 {
+		written := true
+		err := stream.Default.Replay(ctx, actor, uint(after), func(event stream.Event) bool {
+			replayed[event.ID] = true
+			written = writeStreamEvent(c, event)
+			return written
+		})
+		if err != nil {
+			logging.FromContext(ctx).Error("failed to replay events", "after", after, "error", err)
+			return
+		}
+		if !written {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(config.Current.SSE.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, open := <-sub.Events():
+			if !open {
+				return
+			}
+			if replayed[event.ID] {
+				continue
+			}
+			if !writeStreamEvent(c, event) {
+				return
+			}
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			if err := auth.ValidateSession(c.Request.Context(), repository.Default.Sessions, sessionID, actor.ID); err != nil {
+				return
+			}
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+func writeStreamEvent(c *gin.Context, event stream.Event) bool {
+	data, err := json.Marshal(event)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("failed to encode stream event", "event_id", event.ID, "error", err)
+		return true
+	}
+
+	_, err = fmt.Fprintf(c.Writer, "id: %d\nevent: task\ndata: %s\n\n", event.ID, data)
+	return err == nil
+}
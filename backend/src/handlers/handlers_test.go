@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/notify"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	pelaksanaID = 1
+	leaderID    = 2
+	managerID   = 3
+)
+
+var (
+	pelaksana = policy.Actor{ID: pelaksanaID, Role: "pelaksana"}
+	leader    = policy.Actor{ID: leaderID, Role: "leader"}
+)
+
+type recordingNotifier struct {
+	mu     sync.Mutex
+	events []notify.Event
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, event notify.Event) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, event)
+	return nil
+}
+
+func (n *recordingNotifier) take() []notify.Event {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	events := n.events
+	n.events = nil
+	return events
+}
+
+var (
+	published    = &recordingNotifier{}
+	registerOnce sync.Once
+)
+
+func newTestRepositories(t *testing.T) repository.Repositories {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	registerOnce.Do(func() { notify.Register(published) })
+	published.take()
+
+	tasks := repository.NewMemoryTaskRepository(model.Task{
+		ID:             1,
+		Title:          "Task",
+		CreatedBy:      pelaksanaID,
+		AssignedLeader: leaderID,
+		Status:         "Submitted",
+		Version:        1,
+	})
+	users := repository.NewMemoryUserRepository(
+		model.User{ID: pelaksanaID, Username: "pelaksana", Role: "pelaksana", Active: true},
+		model.User{ID: leaderID, Username: "leader", Role: "leader", Active: true},
+		model.User{ID: managerID, Username: "manager", Role: "manager", Active: true},
+	)
+
+	previousRepos, previousService := repository.Default, service.Default
+	t.Cleanup(func() { repository.Default, service.Default = previousRepos, previousService })
+
+	repository.Default = repository.NewMemoryRepositories(tasks, users)
+	service.Default = service.NewTaskService(repository.Default.Tasks, repository.Default.Users)
+	return repository.Default
+}
+
+func perform(handler gin.HandlerFunc, actor policy.Actor, method string, route string, target string, body io.Reader, contentType string) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Handle(method, route, func(c *gin.Context) {
+		c.Set("user_id", float64(actor.ID))
+		c.Set("role", actor.Role)
+	}, handler)
+
+	req := httptest.NewRequest(method, target, body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func performJSON(handler gin.HandlerFunc, actor policy.Actor, method string, route string, target string, payload any) *httptest.ResponseRecorder {
+	encoded, _ := json.Marshal(payload)
+	return perform(handler, actor, method, route, target, bytes.NewReader(encoded), "application/json")
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder, into any) {
+	t.Helper()
+	if err := json.Unmarshal(w.Body.Bytes(), into); err != nil {
+		t.Fatalf("decode %q: %v", w.Body.String(), err)
+	}
+}
+
+func expectStatus(t *testing.T, w *httptest.ResponseRecorder, status int) {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("status = %d, want %d (body %s)", w.Code, status, w.Body.String())
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/ardhia137/task_todo/src/config"
+	"github.com/ardhia137/task_todo/src/database"
+	"github.com/ardhia137/task_todo/src/logging"
+	"github.com/ardhia137/task_todo/src/migrations"
+	"github.com/gin-gonic/gin"
+)
+
+var shuttingDown atomic.Bool
+
+func MarkShuttingDown() {
+	shuttingDown.Store(true)
+}
+
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func Readyz(c *gin.Context) {
+	if shuttingDown.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "shutting_down"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), config.Current.HTTP.ReadinessTimeout)
+	defer cancel()
+
+	checks := gin.H{"database": "ok", "schema": "ok"}
+	ready := true
+
+	sqlDB, err := database.DB.DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+	if err != nil {
+		logging.FromContext(ctx).Warn("readiness database check failed", "error", err)
+		checks["database"] = "unavailable"
+		checks["schema"] = "unknown"
+		ready = false
+	} else if err := migrations.Check(database.DB.WithContext(ctx)); err != nil {
+		logging.FromContext(ctx).Warn("readiness schema check failed", "error", err)
+		checks["schema"] = "unavailable"
+		if errors.Is(err, migrations.ErrSchemaBehind) {
+			checks["schema"] = "behind"
+		}
+		ready = false
+	}
+
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not_ready", "checks": checks})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ready", "checks": checks})
+}
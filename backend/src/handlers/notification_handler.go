@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func GetNotifications(c *gin.Context) {
+	var query model.NotificationListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	bounds := paginate(query.Page, query.PageSize, 0)
+	items, total, err := repository.Default.Notifications.List(c.Request.Context(), repository.NotificationQuery{
+		UserID: userID,
+		Unread: query.Unread,
+		Offset: (bounds.Page - 1) * bounds.PageSize,
+		Limit:  bounds.PageSize,
+	})
+	if err != nil {
+		respondInternalError(c, "Failed to retrieve notifications", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": items,
+		"pagination":    paginate(bounds.Page, bounds.PageSize, total),
+	})
+}
+
+func GetUnreadNotificationCount(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	count, err := repository.Default.Notifications.CountUnread(c.Request.Context(), userID)
+	if err != nil {
+		respondInternalError(c, "Failed to count notifications", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unread": count})
+}
+
+func MarkNotificationRead(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	notificationID, ok := idParam(c, "id", "Invalid notification ID")
+	if !ok {
+		return
+	}
+
+	notification, err := repository.Default.Notifications.Find(c.Request.Context(), userID, notificationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		} else {
+			respondInternalError(c, "Failed to load notification", err)
+		}
+		return
+	}
+
+	if notification.ReadAt == nil {
+		now := time.Now()
+		if err := repository.Default.Notifications.MarkRead(c.Request.Context(), notification.ID, now); err != nil {
+			respondInternalError(c, "Failed to update notification", err)
+			return
+		}
+		notification.ReadAt = &now
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Notification marked as read",
+		"notification": notification,
+	})
+}
+
+func MarkAllNotificationsRead(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	updated, err := repository.Default.Notifications.MarkAllRead(c.Request.Context(), userID, time.Now())
+	if err != nil {
+		respondInternalError(c, "Failed to update notifications", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "All notifications marked as read",
+		"updated": updated,
+	})
+}
@@ -0,0 +1,13 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ardhia137/task_todo/src/logging"
+	"github.com/gin-gonic/gin"
+)
+
+func respondInternalError(c *gin.Context, message string, err error) {
+	logging.FromContext(c.Request.Context()).Error(message, "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
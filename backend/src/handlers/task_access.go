@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/service"
+	"github.com/ardhia137/task_todo/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func currentActor(c *gin.Context) (policy.Actor, bool) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return policy.Actor{}, false
+	}
+
+	role, err := utils.GetRoleFromContext(c)
+	if err != nil {
+		return policy.Actor{}, false
+	}
+
+	return policy.Actor{ID: userID, Role: role}, true
+}
+
+func taskIDParam(c *gin.Context) (uint, bool) {
+	return idParam(c, "id", "Invalid task ID")
+}
+
+func idParam(c *gin.Context, name string, message string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func loadTaskFor(c *gin.Context, action policy.Action) (model.Task, policy.Actor, bool) {
+	taskID, ok := taskIDParam(c)
+	if !ok {
+		return model.Task{}, policy.Actor{}, false
+	}
+
+	actor, ok := currentActor(c)
+	if !ok {
+		return model.Task{}, policy.Actor{}, false
+	}
+
+	task, err := service.Default.Get(c.Request.Context(), actor, taskID, action)
+	if err != nil {
+		if errors.Is(err, policy.ErrTaskNotFound) || errors.Is(err, policy.ErrForbidden) {
+			respondPolicyError(c, err)
+		} else {
+			respondInternalError(c, "Failed to load task", err)
+		}
+		return model.Task{}, policy.Actor{}, false
+	}
+
+	return task, actor, true
+}
+
+func taskETag(task model.Task) string {
+	return fmt.Sprintf(`"%d-%d"`, task.ID, task.Version)
+}
+
+func checkIfMatch(c *gin.Context, task model.Task) error {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return nil
+	}
+
+	current := taskETag(task)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == current {
+			return nil
+		}
+	}
+	return service.ErrVersionMismatch
+}
+
+func respondVersionMismatch(c *gin.Context, task model.Task) {
+	c.Header("ETag", taskETag(task))
+	c.JSON(http.StatusPreconditionFailed, gin.H{
+		"error":   "Task has been modified by someone else, reload it and try again",
+		"version": task.Version,
+	})
+}
+
+func respondPolicyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, policy.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+	case errors.Is(err, policy.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not allowed to perform this action on the task"})
+	default:
+		respondInternalError(c, "Failed to authorize request", err)
+	}
+}
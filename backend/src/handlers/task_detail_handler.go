@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/service"
+	"github.com/ardhia137/task_todo/src/workflow"
+	"github.com/gin-gonic/gin"
+)
+
+func GetTaskDetail(c *gin.Context) {
+	taskID, ok := taskIDParam(c)
+	if !ok {
+		return
+	}
+
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	task, err := service.Default.Detail(c.Request.Context(), actor, taskID)
+	if err != nil {
+		if errors.Is(err, policy.ErrTaskNotFound) || errors.Is(err, policy.ErrForbidden) {
+			respondPolicyError(c, err)
+		} else {
+			respondInternalError(c, "Failed to load task", err)
+		}
+		return
+	}
+
+	c.Header("ETag", taskETag(task))
+	c.JSON(http.StatusOK, gin.H{
+		"task":              task,
+		"available_actions": workflow.Current.Available(task.Status, actor.Role),
+	})
+}
+
+func GetTaskHistories(c *gin.Context) {
+	var query model.HistoryListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, _, ok := loadTaskFor(c, policy.ActionView)
+	if !ok {
+		return
+	}
+
+	bounds := paginate(query.Page, query.PageSize, 0)
+	items, total, err := repository.Default.Histories.List(c.Request.Context(), repository.HistoryQuery{
+		TaskID: task.ID,
+		Field:  query.Field,
+		Desc:   query.Order == "desc",
+		Offset: (bounds.Page - 1) * bounds.PageSize,
+		Limit:  bounds.PageSize,
+	})
+	if err != nil {
+		respondInternalError(c, "Failed to retrieve task histories", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"histories":  items,
+		"pagination": paginate(bounds.Page, bounds.PageSize, total),
+	})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+func TestGetTaskHistoriesFiltersByField(t *testing.T) {
+	repos := newTestRepositories(t)
+	ctx := context.Background()
+
+	actorID := uint(leaderID)
+	for _, field := range []string{"title", "progress", "title"} {
+		task, err := repos.Tasks.Find(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repos.Tasks.Update(ctx, task, map[string]interface{}{"version": task.Version + 1}, &model.TaskHistory{
+			ActionBy: &actorID,
+			Action:   "update",
+			Changes:  []model.TaskHistoryChange{{Field: field}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := perform(GetTaskHistories, pelaksana, http.MethodGet, "/tasks/:id/histories", "/tasks/1/histories?field=title&order=desc", nil, "")
+	expectStatus(t, w, http.StatusOK)
+
+	var body struct {
+		Histories  []model.TaskHistory `json:"histories"`
+		Pagination model.Pagination    `json:"pagination"`
+	}
+	decode(t, w, &body)
+	if body.Pagination.Total != 2 || len(body.Histories) != 2 {
+		t.Fatalf("got %d of %d histories, want 2 of 2", len(body.Histories), body.Pagination.Total)
+	}
+	if body.Histories[0].ID != 3 || body.Histories[1].ID != 1 {
+		t.Fatalf("history ids = %d, %d, want 3, 1", body.Histories[0].ID, body.Histories[1].ID)
+	}
+	if body.Histories[0].ActionUser == nil || body.Histories[0].ActionUser.ID != leaderID {
+		t.Fatalf("action_by = %+v, want the leader", body.Histories[0].ActionUser)
+	}
+
+	w = perform(GetTaskHistories, leader, http.MethodGet, "/tasks/:id/histories", "/tasks/2/histories", nil, "")
+	expectStatus(t, w, http.StatusNotFound)
+}
@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
-	"github.com/ardhia137/task_todo/src/database"
 	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/service"
 	"github.com/ardhia137/task_todo/src/utils"
 	"github.com/gin-gonic/gin"
 )
@@ -19,51 +23,33 @@ func CreateTaskHandler(c *gin.Context) {
 		return
 	}
 
-	createdBy, err := utils.GetUserIDFromContext(c)
-	if err != nil {
+	actor, ok := currentActor(c)
+	if !ok {
 		return
 	}
 
-	dueDate, err := time.Parse("2006-01-02 15:04:05.000", req.DueDate)
+	dueDate, err := parseDueDate(req.DueDate)
 	if err != nil {
-		fmt.Println("Error parsing date:", err)
-	}
-
-	task := model.Task{
-		Title:          req.Title,
-		Description:    req.Description,
-		CreatedBy:      createdBy,
-		AssignedLeader: req.AssigneeID,
-		Status:         "Submitted",
-		Progress:       0,
-		ProgressBy:     createdBy,
-		Deadline:       dueDate,
-	}
-	tx := database.DB.Begin()
-	if err := tx.Create(&task).Error; err != nil {
-		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due_date format"})
 		return
 	}
 
-	history := model.TaskHistory{
-		TaskID:   task.ID,
-		ActionBy: createdBy,
-		Action:   "submit",
-	}
-
-	if err := tx.Create(&history).Error; err != nil {
-		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task history"})
+	task, err := service.Default.Create(c.Request.Context(), actor, service.NewTask{
+		Title:       req.Title,
+		Description: req.Description,
+		LeaderID:    req.AssigneeID,
+		Deadline:    dueDate,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrLeaderNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Leader not found or inactive"})
+		} else {
+			respondInternalError(c, "Failed to create task", err)
+		}
 		return
 	}
 
-	tx.Commit()
-
-	if err := database.DB.Preload("TaskHistories").First(&task, task.ID).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load task with history"})
-		return
-	}
+	c.Header("ETag", taskETag(task))
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Task created successfully",
@@ -71,102 +57,50 @@ func CreateTaskHandler(c *gin.Context) {
 	})
 }
 
-func GetTasksHandler(c *gin.Context) {
-	user_id, err := utils.GetUserIDFromContext(c)
-
-	if err != nil {
-		return
-	}
-	var tasks []model.Task
-	if err := database.DB.
-		Preload("CreatedByUser").
-		Preload("LeaderUser").
-		Preload("ProgressUser").
-		Preload("TaskHistories.ActionUser").
-		Where("created_by = ?", user_id).
-		Find(&tasks).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tasks"})
-		return
+func parseDueDate(value string) (time.Time, error) {
+	layouts := []string{"2006-01-02 15:04:05.000", "2006-01-02 15:04:05", "2006-01-02"}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
 	}
+	return time.Parse(time.RFC3339, value)
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"tasks": tasks,
-	})
+func GetTasksHandler(c *gin.Context) {
+	listTasks(c)
 }
 
 func UpdateTask(c *gin.Context) {
 	var req model.TaskRequest
 
-	taskID := c.Param("id")
-	if taskID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
-		return
-	}
-
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
-		return
-	}
-
-	updatedBy, ok := userID.(float64)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
-		return
-	}
-
-	var existingTask model.Task
-	if err := database.DB.First(&existingTask, taskID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
-		return
-	}
-
-	if existingTask.Status != "Revision" {
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": fmt.Sprintf("Task cannot be updated because status is '%s'", existingTask.Status),
-		})
-		return
-	}
-
-	dueDate, err := time.Parse("2006-01-02 15:04:05.000", req.DueDate)
+	updatedBy, err := utils.GetUserIDFromContext(c)
 	if err != nil {
-		fmt.Println("Error parsing date:", err)
-	}
-
-	updateData := model.Task{
-		Title:          req.Title,
-		Description:    req.Description,
-		CreatedBy:      uint(updatedBy),
-		AssignedLeader: req.AssigneeID,
-		Status:         "Submitted",
-		Progress:       0,
-		ProgressBy:     uint(updatedBy),
-		Deadline:       dueDate,
-	}
-
-	if err := database.DB.Model(&existingTask).Updates(updateData).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task"})
 		return
 	}
 
-	history := model.TaskHistory{
-		TaskID:   existingTask.ID,
-		ActionBy: uint(updatedBy),
-		Action:   "submit",
-	}
-	if err := database.DB.Create(&history).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record history"})
+	dueDate, err := parseDueDate(req.DueDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due_date format"})
 		return
 	}
 
-	var updatedTask model.Task
-	if err := database.DB.Preload("TaskHistories").First(&updatedTask, existingTask.ID).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load updated task"})
+	updatedTask, ok := transitionTask(c, policy.ActionUpdate, "submit", "", map[string]interface{}{
+		"title":            req.Title,
+		"description":      req.Description,
+		"assigned_leader":  req.AssigneeID,
+		"progress":         0,
+		"progress_by":      updatedBy,
+		"deadline":         dueDate,
+		"reminder_sent_at": nil,
+		"overdue_at":       nil,
+	})
+	if !ok {
 		return
 	}
 
@@ -177,84 +111,28 @@ func UpdateTask(c *gin.Context) {
 }
 
 func UpdateProgress(c *gin.Context) {
-	var req struct {
-		Progress int    `json:"progress" binding:"required"`
-		Note     string `json:"note"`
-	}
-
-	taskID := c.Param("id")
-	if taskID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
-		return
-	}
+	var req model.ProgressRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
-		return
-	}
-
-	updatedBy, ok := userID.(float64)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
-		return
-	}
-
-	var existingTask model.Task
-	if err := database.DB.First(&existingTask, taskID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
-		return
-	}
-
-	if existingTask.Status != "Approved by Leader" && existingTask.Status != "In Progress" {
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": fmt.Sprintf("Task cannot be updated because status is '%s'", existingTask.Status),
-		})
+	updatedBy, err := utils.GetUserIDFromContext(c)
+	if err != nil {
 		return
 	}
 
-	newStatus := existingTask.Status
-	if req.Progress == 100 {
-		newStatus = "Completed"
-	} else if req.Progress > 0 {
-		newStatus = "In Progress"
-	}
-
-	if err := database.DB.Model(&existingTask).Updates(model.Task{
-		Progress:   req.Progress,
-		ProgressBy: uint(updatedBy),
-		Status:     newStatus,
-	}).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task progress"})
-		return
-	}
-	newAction := "update_progress"
-	if req.Progress == 100 {
-		newAction = "complete"
-	}
+	progress := *req.Progress
 	if req.Note == "" {
-		req.Note = fmt.Sprintf("Progress updated to %d%%", req.Progress)
-	}
-
-	history := model.TaskHistory{
-		TaskID:   existingTask.ID,
-		ActionBy: uint(updatedBy),
-		Action:   newAction,
-		Note:     req.Note,
-	}
-	if err := database.DB.Create(&history).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record history"})
-		return
+		req.Note = fmt.Sprintf("Progress updated to %d%%", progress)
 	}
 
-	var updatedTask model.Task
-	if err := database.DB.Preload("TaskHistories").First(&updatedTask, existingTask.ID).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load updated task"})
+	updatedTask, ok := transitionTask(c, policy.ActionProgress, progressAction(progress), req.Note, map[string]interface{}{
+		"progress":    progress,
+		"progress_by": updatedBy,
+	})
+	if !ok {
 		return
 	}
 
@@ -265,179 +143,118 @@ func UpdateProgress(c *gin.Context) {
 }
 
 func GetTaskByLeaderId(c *gin.Context) {
+	listTasks(c)
+}
 
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
-		return
+func RevisionTask(c *gin.Context) {
+	var req struct {
+		Note string `json:"note" binding:"required"`
 	}
 
-	leaderID, ok := userID.(float64)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	var tasks []model.Task
-
-	if err := database.DB.
-		Preload("CreatedByUser").
-		Preload("LeaderUser").
-		Preload("ProgressUser").
-		Preload("TaskHistories.ActionUser").
-		Where("assigned_leader = ? AND status IN ?", leaderID, []string{"Submitted", "In Progress"}).
-		Find(&tasks).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tasks"})
+	updatedTask, ok := transitionTask(c, policy.ActionTransition, "revision", req.Note, nil)
+	if !ok {
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"tasks": tasks,
+		"message": "Task revised successfully",
+		"task":    updatedTask,
 	})
 }
 
-func RevisionTask(c *gin.Context) {
+func ApproveTask(c *gin.Context) {
 	var req struct {
-		Note string `json:"note" binding:"required"`
+		Note string `json:"note"`
 	}
 
-	taskID := c.Param("id")
-	if taskID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
-		return
-	}
-
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
-		return
-	}
-
-	leaderID, ok := userID.(float64)
+	updatedTask, ok := transitionTask(c, policy.ActionTransition, "approve", req.Note, nil)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
 		return
 	}
 
-	var existingTask model.Task
-	if err := database.DB.First(&existingTask, taskID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
-		return
-	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Task approved successfully",
+		"task":    updatedTask,
+	})
+}
 
-	if existingTask.Status != "Submitted" {
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": fmt.Sprintf("Task cannot be revised because status is '%s'", existingTask.Status),
-		})
+func ProgressOverride(c *gin.Context) {
+	var req model.ProgressRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err := database.DB.Model(&existingTask).Updates(model.Task{
-		Status: "Revision",
-	}).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task status"})
+	leaderID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
 		return
 	}
 
-	history := model.TaskHistory{
-		TaskID:   existingTask.ID,
-		ActionBy: uint(leaderID),
-		Action:   "revision",
-		Note:     req.Note,
-	}
-	if err := database.DB.Create(&history).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record history"})
-		return
+	progress := *req.Progress
+	if req.Note == "" {
+		req.Note = fmt.Sprintf("Progress overridden to %d%%", progress)
 	}
 
-	var updatedTask model.Task
-	if err := database.DB.Preload("TaskHistories").First(&updatedTask, existingTask.ID).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load updated task"})
+	updatedTask, ok := transitionTask(c, policy.ActionProgress, progressAction(progress), req.Note, map[string]interface{}{
+		"progress":    progress,
+		"progress_by": leaderID,
+	})
+	if !ok {
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Task revised successfully",
+		"message": "Task progress overridden successfully",
 		"task":    updatedTask,
 	})
 }
 
-func ApproveTask(c *gin.Context) {
-	taskID := c.Param("id")
-	if taskID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
-		return
-	}
-
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
-		return
-	}
-
-	leaderID, ok := userID.(float64)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
-		return
-	}
-
-	var existingTask model.Task
-	if err := database.DB.First(&existingTask, taskID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
-		return
+func progressAction(progress int) string {
+	if progress == 100 {
+		return "complete"
 	}
+	return "update_progress"
+}
 
-	if existingTask.Status != "Submitted" {
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": fmt.Sprintf("Task cannot be approved because status is '%s'", existingTask.Status),
-		})
-		return
-	}
+func GetTaskManager(c *gin.Context) {
+	listTasks(c)
+}
 
-	if err := database.DB.Model(&existingTask).Updates(model.Task{
-		Status: "Approved by Leader",
-	}).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task status"})
-		return
+func ManagerApproveTask(c *gin.Context) {
+	var req struct {
+		Note string `json:"note"`
 	}
 
-	history := model.TaskHistory{
-		TaskID:   existingTask.ID,
-		ActionBy: uint(leaderID),
-		Action:   "approve",
-	}
-	if err := database.DB.Create(&history).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record history"})
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	var updatedTask model.Task
-	if err := database.DB.Preload("TaskHistories").First(&updatedTask, existingTask.ID).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load updated task"})
+	updatedTask, ok := transitionTask(c, policy.ActionTransition, "manager_approve", req.Note, nil)
+	if !ok {
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Task approved successfully",
+		"message": "Task approved by manager successfully",
 		"task":    updatedTask,
 	})
 }
 
-func ProgressOverride(c *gin.Context) {
+func ReturnTaskToLeader(c *gin.Context) {
 	var req struct {
-		Progress int    `json:"progress" binding:"required"`
-		Note     string `json:"note"`
-	}
-
-	taskID := c.Param("id")
-	if taskID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
-		return
+		Note string `json:"note" binding:"required"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -445,110 +262,86 @@ func ProgressOverride(c *gin.Context) {
 		return
 	}
 
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
-		return
-	}
-
-	leaderID, ok := userID.(float64)
+	updatedTask, ok := transitionTask(c, policy.ActionTransition, "return", req.Note, nil)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID type"})
 		return
 	}
 
-	var existingTask model.Task
-	if err := database.DB.First(&existingTask, taskID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
-		return
-	}
-
-	newStatus := existingTask.Status
-	if req.Progress == 100 {
-		newStatus = "Completed"
-	} else if req.Progress > 0 {
-		newStatus = "In Progress"
-	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Task returned to leader successfully",
+		"task":    updatedTask,
+	})
+}
 
-	if err := database.DB.Model(&existingTask).Updates(model.Task{
-		Progress:   req.Progress,
-		ProgressBy: uint(leaderID),
-		Status:     newStatus,
-	}).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task progress"})
-		return
-	}
-	newAction := "update_progress"
-	if req.Progress == 100 {
-		newAction = "complete"
-	}
-	if req.Note == "" {
-		req.Note = fmt.Sprintf("Progress overridden to %d%%", req.Progress)
+func RejectTask(c *gin.Context) {
+	var req struct {
+		Note string `json:"note" binding:"required"`
 	}
 
-	history := model.TaskHistory{
-		TaskID:   existingTask.ID,
-		ActionBy: uint(leaderID),
-		Action:   newAction,
-		Note:     req.Note,
-	}
-	if err := database.DB.Create(&history).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record history"})
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	var updatedTask model.Task
-	if err := database.DB.Preload("TaskHistories").First(&updatedTask, existingTask.ID).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load updated task"})
+	updatedTask, ok := transitionTask(c, policy.ActionTransition, "reject", req.Note, nil)
+	if !ok {
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Task progress overridden successfully",
+		"message": "Task rejected successfully",
 		"task":    updatedTask,
 	})
 }
 
-func GetTaskManager(c *gin.Context) {
-	var tasks []model.Task
-
-	if err := database.DB.
-		Preload("CreatedByUser").
-		Preload("LeaderUser").
-		Preload("ProgressUser").
-		Preload("TaskHistories.ActionUser").
-		Where("status IN ?", []string{"Approved by Leader", "In Progress", "Completed"}).
-		Find(&tasks).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tasks"})
+func GetLeader(c *gin.Context) {
+	leaders, err := service.Default.Leaders(c.Request.Context())
+	if err != nil {
+		respondInternalError(c, "Failed to retrieve leaders", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"tasks": tasks})
+	c.JSON(http.StatusOK, gin.H{"leaders": leaders})
 }
 
-func GetLeader(c *gin.Context) {
-	var leaders []model.User
-
-	if err := database.DB.Where("role = ?", "leader").Find(&leaders).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve leaders"})
+func DeleteTask(c *gin.Context) {
+	taskID, ok := taskIDParam(c)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"leaders": leaders})
-}
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
 
-func DeleteTask(c *gin.Context) {
-	taskID := c.Param("id")
-	if taskID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+	keys, err := repository.Default.Attachments.StorageKeys(c.Request.Context(), taskID)
+	if err != nil {
+		respondInternalError(c, "Failed to load task attachments", err)
 		return
 	}
 
-	if err := database.DB.Delete(&model.Task{}, taskID).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete task"})
+	task, err := service.Default.Delete(c.Request.Context(), service.DeleteInput{
+		TaskID: taskID,
+		Actor:  actor,
+		Precondition: func(task model.Task) error {
+			return checkIfMatch(c, task)
+		},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, policy.ErrTaskNotFound), errors.Is(err, policy.ErrForbidden):
+			respondPolicyError(c, err)
+		case errors.Is(err, service.ErrVersionMismatch):
+			respondVersionMismatch(c, task)
+		default:
+			respondInternalError(c, "Failed to delete task", err)
+		}
 		return
 	}
 
+	removeTaskAttachments(c, keys)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Task deleted successfully",
 	})
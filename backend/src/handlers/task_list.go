@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var taskSortColumns = map[string]string{
+	"id":       "id",
+	"title":    "title",
+	"status":   "status",
+	"progress": "progress",
+	"deadline": "deadline",
+}
+
+func listTasks(c *gin.Context) {
+	var query model.TaskListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	taskQuery, err := taskFilters(query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskQuery.Sort, err = taskOrder(query.Sort)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bounds := paginate(query.Page, query.PageSize, 0)
+	taskQuery.Offset = (bounds.Page - 1) * bounds.PageSize
+	taskQuery.Limit = bounds.PageSize
+	taskQuery.WithHistories = c.Query("include") == "histories"
+
+	tasks, total, err := service.Default.List(c.Request.Context(), actor, taskQuery)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			respondPolicyError(c, err)
+		} else {
+			respondInternalError(c, "Failed to retrieve tasks", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tasks":      tasks,
+		"pagination": paginate(bounds.Page, bounds.PageSize, total),
+	})
+}
+
+func taskFilters(query model.TaskListQuery) (repository.TaskQuery, error) {
+	var filters repository.TaskQuery
+	for _, status := range query.Status {
+		for _, s := range strings.Split(status, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				filters.Statuses = append(filters.Statuses, s)
+			}
+		}
+	}
+
+	filters.LeaderID = query.LeaderID
+	filters.CreatedBy = query.CreatedBy
+
+	if query.DeadlineFrom != "" {
+		from, _, err := parseDateFilter(query.DeadlineFrom)
+		if err != nil {
+			return filters, fmt.Errorf("invalid deadline_from: %w", err)
+		}
+		filters.DeadlineFrom = &from
+	}
+	if query.DeadlineTo != "" {
+		to, dateOnly, err := parseDateFilter(query.DeadlineTo)
+		if err != nil {
+			return filters, fmt.Errorf("invalid deadline_to: %w", err)
+		}
+		if dateOnly {
+			before := to.AddDate(0, 0, 1)
+			filters.DeadlineBefore = &before
+		} else {
+			filters.DeadlineUntil = &to
+		}
+	}
+
+	filters.ProgressMin = query.ProgressMin
+	filters.ProgressMax = query.ProgressMax
+	filters.Overdue = query.Overdue
+	filters.Escalated = query.Escalated
+	filters.Search = strings.TrimSpace(query.Search)
+
+	return filters, nil
+}
+
+func parseDateFilter(value string) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
+func taskOrder(sort string) ([]repository.TaskSort, error) {
+	if sort == "" {
+		return []repository.TaskSort{{Column: "id", Desc: true}}, nil
+	}
+
+	var orders []repository.TaskSort
+	desc := false
+	hasID := false
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+		desc = strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+
+		column, ok := taskSortColumns[field]
+		if !ok {
+			return nil, fmt.Errorf("invalid sort field '%s'", field)
+		}
+		if column == "id" {
+			hasID = true
+		}
+		orders = append(orders, repository.TaskSort{Column: column, Desc: desc})
+	}
+	if !hasID {
+		orders = append(orders, repository.TaskSort{Column: "id", Desc: desc})
+	}
+
+	return orders, nil
+}
+
+func paginate(page, pageSize int, total int64) model.Pagination {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+
+	return model.Pagination{
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type fieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+func userAudit(actionBy uint, action string, changes map[string]fieldChange) (*model.UserAudit, error) {
+	var encoded []byte
+	if len(changes) > 0 {
+		var err error
+		encoded, err = json.Marshal(changes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.UserAudit{
+		ActionBy: actionBy,
+		Action:   action,
+		Changes:  string(encoded),
+	}, nil
+}
+
+func loadUser(c *gin.Context) (model.User, bool) {
+	userID, ok := idParam(c, "id", "Invalid user ID")
+	if !ok {
+		return model.User{}, false
+	}
+
+	user, err := repository.Default.Users.Find(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		} else {
+			respondInternalError(c, "Failed to load user", err)
+		}
+		return model.User{}, false
+	}
+
+	return user, true
+}
+
+func GetUsers(c *gin.Context) {
+	filter := repository.UserFilter{Role: c.Query("role")}
+	if active := c.Query("active"); active != "" {
+		isActive := active == "true"
+		filter.Active = &isActive
+	}
+
+	users, err := repository.Default.Users.List(c.Request.Context(), filter)
+	if err != nil {
+		respondInternalError(c, "Failed to retrieve users", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
+
+func GetUser(c *gin.Context) {
+	user, ok := loadUser(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+func CreateUser(c *gin.Context) {
+	var req model.CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	managerID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	taken, err := repository.Default.Users.UsernameTaken(c.Request.Context(), req.Username, 0)
+	if err != nil {
+		respondInternalError(c, "Failed to check username", err)
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "Username already exists"})
+		return
+	}
+
+	if err := utils.CurrentPasswordPolicy().Validate(req.Password); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		respondInternalError(c, "Failed to hash password", err)
+		return
+	}
+
+	user := model.User{
+		Username: req.Username,
+		Password: hash,
+		Email:    req.Email,
+		Role:     req.Role,
+		Active:   true,
+	}
+
+	audit, err := userAudit(managerID, "create", map[string]fieldChange{
+		"username": {New: user.Username},
+		"email":    {New: user.Email},
+		"role":     {New: user.Role},
+	})
+	if err != nil {
+		respondInternalError(c, "Failed to create user", err)
+		return
+	}
+
+	if err := repository.Default.Users.Create(c.Request.Context(), &user, audit); err != nil {
+		respondInternalError(c, "Failed to create user", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "User created successfully",
+		"user":    user,
+	})
+}
+
+func UpdateUser(c *gin.Context) {
+	var req model.UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	managerID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	user, ok := loadUser(c)
+	if !ok {
+		return
+	}
+
+	updates := map[string]interface{}{}
+	changes := map[string]fieldChange{}
+
+	if req.Username != user.Username {
+		taken, err := repository.Default.Users.UsernameTaken(c.Request.Context(), req.Username, user.ID)
+		if err != nil {
+			respondInternalError(c, "Failed to check username", err)
+			return
+		}
+		if taken {
+			c.JSON(http.StatusConflict, gin.H{"error": "Username already exists"})
+			return
+		}
+
+		updates["username"] = req.Username
+		changes["username"] = fieldChange{Old: user.Username, New: req.Username}
+	}
+
+	if req.Email != nil && *req.Email != user.Email {
+		updates["email"] = *req.Email
+		changes["email"] = fieldChange{Old: user.Email, New: *req.Email}
+	}
+
+	if len(updates) == 0 {
+		c.JSON(http.StatusOK, gin.H{"message": "User updated successfully", "user": user})
+		return
+	}
+
+	audit, err := userAudit(managerID, "update", changes)
+	if err != nil {
+		respondInternalError(c, "Failed to update user", err)
+		return
+	}
+
+	if err := repository.Default.Users.Update(c.Request.Context(), user, repository.UserChange{Updates: updates, Audit: audit}); err != nil {
+		respondInternalError(c, "Failed to update user", err)
+		return
+	}
+
+	user.Username = req.Username
+	if req.Email != nil {
+		user.Email = *req.Email
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User updated successfully",
+		"user":    user,
+	})
+}
+
+func ChangeUserRole(c *gin.Context) {
+	var req model.ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	managerID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	user, ok := loadUser(c)
+	if !ok {
+		return
+	}
+
+	if user.ID == managerID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You cannot change your own role"})
+		return
+	}
+
+	audit, err := userAudit(managerID, "change_role", map[string]fieldChange{
+		"role": {Old: user.Role, New: req.Role},
+	})
+	if err != nil {
+		respondInternalError(c, "Failed to change user role", err)
+		return
+	}
+
+	if err := repository.Default.Users.Update(c.Request.Context(), user, repository.UserChange{
+		Updates:        map[string]interface{}{"role": req.Role},
+		RevokeSessions: true,
+		Audit:          audit,
+	}); err != nil {
+		respondInternalError(c, "Failed to change user role", err)
+		return
+	}
+	user.Role = req.Role
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User role changed successfully",
+		"user":    user,
+	})
+}
+
+func DeactivateUser(c *gin.Context) {
+	setUserActive(c, false)
+}
+
+func ActivateUser(c *gin.Context) {
+	setUserActive(c, true)
+}
+
+func setUserActive(c *gin.Context, active bool) {
+	managerID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	user, ok := loadUser(c)
+	if !ok {
+		return
+	}
+
+	if user.ID == managerID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You cannot change the status of your own account"})
+		return
+	}
+
+	action := "activate"
+	message := "User activated successfully"
+	if !active {
+		action = "deactivate"
+		message = "User deactivated successfully"
+	}
+
+	if user.Active == active {
+		c.JSON(http.StatusOK, gin.H{"message": message, "user": user})
+		return
+	}
+
+	audit, err := userAudit(managerID, action, map[string]fieldChange{
+		"active": {Old: !active, New: active},
+	})
+	if err != nil {
+		respondInternalError(c, "Failed to update user status", err)
+		return
+	}
+
+	if err := repository.Default.Users.Update(c.Request.Context(), user, repository.UserChange{
+		Updates:        map[string]interface{}{"active": active},
+		RevokeSessions: !active,
+		Audit:          audit,
+	}); err != nil {
+		respondInternalError(c, "Failed to update user status", err)
+		return
+	}
+	user.Active = active
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"user":    user,
+	})
+}
+
+func GetUserAudits(c *gin.Context) {
+	user, ok := loadUser(c)
+	if !ok {
+		return
+	}
+
+	audits, err := repository.Default.Users.Audits(c.Request.Context(), user.ID)
+	if err != nil {
+		respondInternalError(c, "Failed to retrieve user audits", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"audits": audits})
+}
@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/utils"
+	"github.com/ardhia137/task_todo/src/webhook"
+	"github.com/ardhia137/task_todo/src/workflow"
+	"github.com/gin-gonic/gin"
+)
+
+func loadWebhook(c *gin.Context) (model.Webhook, bool) {
+	hookID, ok := idParam(c, "id", "Invalid webhook ID")
+	if !ok {
+		return model.Webhook{}, false
+	}
+
+	hook, err := repository.Default.Webhooks.Find(c.Request.Context(), hookID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+		} else {
+			respondInternalError(c, "Failed to load webhook", err)
+		}
+		return model.Webhook{}, false
+	}
+	return hook, true
+}
+
+func validWebhookURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func unknownWebhookEvent(events []string) (string, bool) {
+	for _, name := range events {
+		if !webhook.KnownEvent(workflow.Current, name) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func GetWebhooks(c *gin.Context) {
+	hooks, err := repository.Default.Webhooks.List(c.Request.Context())
+	if err != nil {
+		respondInternalError(c, "Failed to retrieve webhooks", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": hooks})
+}
+
+func GetWebhook(c *gin.Context) {
+	hook, ok := loadWebhook(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhook": hook})
+}
+
+func CreateWebhook(c *gin.Context) {
+	var req model.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !validWebhookURL(req.URL) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Webhook URL must use http or https"})
+		return
+	}
+
+	if name, ok := unknownWebhookEvent(req.Events); ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unknown webhook event '%s'", name)})
+		return
+	}
+
+	managerID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		respondInternalError(c, "Failed to generate webhook secret", err)
+		return
+	}
+
+	hook := model.Webhook{
+		URL:         req.URL,
+		Secret:      secret,
+		Events:      req.Events,
+		Description: req.Description,
+		Active:      req.Active == nil || *req.Active,
+		CreatedBy:   managerID,
+	}
+	if err := repository.Default.Webhooks.Create(c.Request.Context(), &hook); err != nil {
+		respondInternalError(c, "Failed to create webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Webhook created successfully",
+		"webhook": hook,
+		"secret":  secret,
+	})
+}
+
+func UpdateWebhook(c *gin.Context) {
+	var req model.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !validWebhookURL(req.URL) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Webhook URL must use http or https"})
+		return
+	}
+
+	if name, ok := unknownWebhookEvent(req.Events); ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unknown webhook event '%s'", name)})
+		return
+	}
+
+	hook, ok := loadWebhook(c)
+	if !ok {
+		return
+	}
+
+	hook.URL = req.URL
+	hook.Events = req.Events
+	hook.Description = req.Description
+	if req.Active != nil {
+		hook.Active = *req.Active
+	}
+
+	if err := repository.Default.Webhooks.Update(c.Request.Context(), hook); err != nil {
+		respondInternalError(c, "Failed to update webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook updated successfully",
+		"webhook": hook,
+	})
+}
+
+func RotateWebhookSecret(c *gin.Context) {
+	hook, ok := loadWebhook(c)
+	if !ok {
+		return
+	}
+
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		respondInternalError(c, "Failed to generate webhook secret", err)
+		return
+	}
+
+	if err := repository.Default.Webhooks.RotateSecret(c.Request.Context(), hook.ID, secret); err != nil {
+		respondInternalError(c, "Failed to rotate webhook secret", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook secret rotated successfully",
+		"secret":  secret,
+	})
+}
+
+func DeleteWebhook(c *gin.Context) {
+	hook, ok := loadWebhook(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.Default.Webhooks.Delete(c.Request.Context(), hook); err != nil {
+		respondInternalError(c, "Failed to delete webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook deleted successfully",
+	})
+}
+
+func GetWebhookDeliveries(c *gin.Context) {
+	var query model.DeliveryListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hook, ok := loadWebhook(c)
+	if !ok {
+		return
+	}
+
+	bounds := paginate(query.Page, query.PageSize, 0)
+	items, total, err := repository.Default.Webhooks.Deliveries(c.Request.Context(), repository.DeliveryQuery{
+		WebhookID: hook.ID,
+		Status:    query.Status,
+		Offset:    (bounds.Page - 1) * bounds.PageSize,
+		Limit:     bounds.PageSize,
+	})
+	if err != nil {
+		respondInternalError(c, "Failed to retrieve deliveries", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": items,
+		"pagination": paginate(bounds.Page, bounds.PageSize, total),
+	})
+}
+
+func RedeliverWebhook(c *gin.Context) {
+	hook, ok := loadWebhook(c)
+	if !ok {
+		return
+	}
+
+	if !hook.Active {
+		c.JSON(http.StatusConflict, gin.H{"error": "Webhook is disabled"})
+		return
+	}
+
+	deliveryID, ok := idParam(c, "deliveryId", "Invalid delivery ID")
+	if !ok {
+		return
+	}
+
+	original, err := repository.Default.Webhooks.FindDelivery(c.Request.Context(), hook.ID, deliveryID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+		} else {
+			respondInternalError(c, "Failed to load delivery", err)
+		}
+		return
+	}
+
+	delivery := model.WebhookDelivery{
+		WebhookID:     hook.ID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		NextAttemptAt: time.Now(),
+	}
+	if err := repository.Default.Webhooks.CreateDelivery(c.Request.Context(), &delivery); err != nil {
+		respondInternalError(c, "Failed to queue redelivery", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":  "Delivery queued for redelivery",
+		"delivery": delivery,
+	})
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/service"
+	"github.com/ardhia137/task_todo/src/workflow"
+	"github.com/gin-gonic/gin"
+)
+
+func transitionTask(c *gin.Context, permission policy.Action, action string, note string, changes map[string]interface{}) (model.Task, bool) {
+	taskID, ok := taskIDParam(c)
+	if !ok {
+		return model.Task{}, false
+	}
+
+	actor, ok := currentActor(c)
+	if !ok {
+		return model.Task{}, false
+	}
+
+	updatedTask, err := service.Default.Transition(c.Request.Context(), service.TransitionInput{
+		TaskID:     taskID,
+		Actor:      actor,
+		Permission: permission,
+		Action:     action,
+		Note:       note,
+		Changes:    changes,
+		Precondition: func(task model.Task) error {
+			return checkIfMatch(c, task)
+		},
+	})
+	if err != nil {
+		var wfErr *service.WorkflowError
+		switch {
+		case errors.As(err, &wfErr):
+			respondWorkflowError(c, wfErr.Err, action, actor.Role, wfErr.Status)
+		case errors.Is(err, policy.ErrTaskNotFound), errors.Is(err, policy.ErrForbidden):
+			respondPolicyError(c, err)
+		case errors.Is(err, service.ErrVersionMismatch):
+			respondVersionMismatch(c, updatedTask)
+		case errors.Is(err, service.ErrLeaderNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Leader not found or inactive"})
+		default:
+			respondInternalError(c, "Failed to update task", err)
+		}
+		return model.Task{}, false
+	}
+
+	c.Header("ETag", taskETag(updatedTask))
+	return updatedTask, true
+}
+
+func respondWorkflowError(c *gin.Context, err error, action, role, status string) {
+	switch {
+	case errors.Is(err, workflow.ErrUnknownAction):
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unknown action '%s'", action)})
+	case errors.Is(err, workflow.ErrInternalAction):
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Action '%s' cannot be performed through this endpoint", action)})
+	case errors.Is(err, workflow.ErrNoteRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Note is required for action '%s'", action)})
+	case errors.Is(err, workflow.ErrRoleNotAllowed):
+		c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("Role '%s' cannot perform action '%s'", role, action)})
+	case errors.Is(err, workflow.ErrInvalidState):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": fmt.Sprintf("Action '%s' is not allowed because status is '%s'", action, status),
+		})
+	default:
+		respondInternalError(c, "Failed to apply workflow action", err)
+	}
+}
+
+func TransitionTask(c *gin.Context) {
+	var req model.TransitionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if transition, ok := workflow.Current.Find(req.Action); ok && transition.Internal {
+		respondWorkflowError(c, workflow.ErrInternalAction, req.Action, "", "")
+		return
+	}
+
+	updatedTask, ok := transitionTask(c, policy.ActionTransition, req.Action, req.Note, nil)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": fmt.Sprintf("Action '%s' applied successfully", req.Action),
+		"task":    updatedTask,
+	})
+}
+
+func GetWorkflow(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"workflow": workflow.Current})
+}
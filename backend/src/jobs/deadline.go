@@ -0,0 +1,101 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/config"
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/notify"
+	"github.com/ardhia137/task_todo/src/scheduler"
+	"github.com/ardhia137/task_todo/src/workflow"
+	"gorm.io/gorm"
+)
+
+func DeadlineJob(db *gorm.DB) scheduler.Job {
+	return scheduler.Job{
+		Name:     "deadline",
+		Interval: config.Current.Scheduler.DeadlineInterval,
+		Run: func(ctx context.Context) error {
+			return CheckDeadlines(ctx, db, time.Now())
+		},
+	}
+}
+
+func CheckDeadlines(ctx context.Context, db *gorm.DB, now time.Time) error {
+	db = db.WithContext(ctx)
+	window := config.Current.Scheduler.ReminderBefore
+	open := workflow.Current.OpenStates()
+
+	var upcoming []model.Task
+	if err := db.
+		Where("status IN ? AND reminder_sent_at IS NULL AND overdue_at IS NULL", open).
+		Where("deadline > ? AND deadline <= ?", now, now.Add(window)).
+		Find(&upcoming).Error; err != nil {
+		return err
+	}
+
+	for _, task := range upcoming {
+		note := fmt.Sprintf("Deadline is approaching: %s", task.Deadline.Format("2006-01-02 15:04"))
+		if err := markTask(ctx, db, task, "reminder_sent_at", "deadline_reminder", note, now); err != nil {
+			return err
+		}
+	}
+
+	var overdue []model.Task
+	if err := db.
+		Where("status IN ? AND overdue_at IS NULL", open).
+		Where("deadline > ? AND deadline <= ?", time.Unix(0, 0), now).
+		Find(&overdue).Error; err != nil {
+		return err
+	}
+
+	for _, task := range overdue {
+		note := fmt.Sprintf("Task is overdue since %s", task.Deadline.Format("2006-01-02 15:04"))
+		if err := markTask(ctx, db, task, "overdue_at", "overdue", note, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func markTask(ctx context.Context, db *gorm.DB, task model.Task, column, action, note string, now time.Time) error {
+	claimed := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Task{}).
+			Where("id = ? AND "+column+" IS NULL", task.ID).
+			Updates(map[string]interface{}{
+				column:    now,
+				"version": gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		claimed = true
+		task.Version++
+
+		return tx.Create(&model.TaskHistory{
+			TaskID: task.ID,
+			Action: action,
+			Note:   note,
+		}).Error
+	})
+	if err != nil || !claimed {
+		return err
+	}
+
+	notify.Publish(ctx, notify.Event{
+		Type:       action,
+		Task:       task,
+		Note:       note,
+		Recipients: []uint{task.CreatedBy, task.AssignedLeader},
+		OccurredAt: now,
+	})
+	return nil
+}
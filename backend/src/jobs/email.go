@@ -0,0 +1,94 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/config"
+	"github.com/ardhia137/task_todo/src/email"
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/scheduler"
+	"gorm.io/gorm"
+)
+
+const emailBatchSize = 50
+
+func EmailJob(db *gorm.DB, sender email.Sender) scheduler.Job {
+	return scheduler.Job{
+		Name:     "email",
+		Interval: config.Current.Email.Interval,
+		Run: func(ctx context.Context) error {
+			return DeliverEmails(ctx, db, sender, time.Now())
+		},
+	}
+}
+
+func DeliverEmails(ctx context.Context, db *gorm.DB, sender email.Sender, now time.Time) error {
+	db = db.WithContext(ctx)
+	maxAttempts := config.Current.Email.MaxAttempts
+	baseDelay := config.Current.Email.RetryBackoff
+
+	var pending []model.EmailOutbox
+	if err := db.
+		Where("sent_at IS NULL AND failed_at IS NULL AND next_attempt_at <= ?", now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(emailBatchSize).
+		Find(&pending).Error; err != nil {
+		return err
+	}
+
+	for _, message := range pending {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		result := db.Model(&model.EmailOutbox{}).
+			Where("id = ? AND attempts = ? AND sent_at IS NULL", message.ID, message.Attempts).
+			Updates(map[string]interface{}{
+				"attempts":        message.Attempts + 1,
+				"next_attempt_at": now.Add(baseDelay),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		attempts := message.Attempts + 1
+
+		sendErr := sender.Send(ctx, email.Message{
+			To:      message.Recipient,
+			Subject: message.Subject,
+			Body:    message.Body,
+		})
+
+		updates := map[string]interface{}{}
+		if sendErr == nil {
+			updates["sent_at"] = time.Now()
+			updates["last_error"] = ""
+		} else {
+			slog.Warn("email delivery failed", "email_id", message.ID, "recipient", message.Recipient, "attempt", attempts, "max_attempts", maxAttempts, "error", sendErr)
+			updates["last_error"] = sendErr.Error()
+			if attempts >= maxAttempts {
+				updates["failed_at"] = time.Now()
+			} else {
+				updates["next_attempt_at"] = now.Add(retryBackoff(baseDelay, attempts))
+			}
+		}
+
+		if err := db.Model(&model.EmailOutbox{}).Where("id = ?", message.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func retryBackoff(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < 24*time.Hour; i++ {
+		delay *= 2
+	}
+	return delay
+}
@@ -0,0 +1,129 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/config"
+	"github.com/ardhia137/task_todo/src/escalation"
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/notify"
+	"github.com/ardhia137/task_todo/src/scheduler"
+	"github.com/ardhia137/task_todo/src/workflow"
+	"gorm.io/gorm"
+)
+
+func EscalationJob(db *gorm.DB) scheduler.Job {
+	return scheduler.Job{
+		Name:     "escalation",
+		Interval: config.Current.Scheduler.EscalationInterval,
+		Run: func(ctx context.Context) error {
+			return CheckEscalations(ctx, db, time.Now())
+		},
+	}
+}
+
+func CheckEscalations(ctx context.Context, db *gorm.DB, now time.Time) error {
+	db = db.WithContext(ctx)
+	rules := escalation.Current
+
+	statuses := rules.Statuses()
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	var tasks []model.Task
+	if err := db.Where("status IN ? AND escalated_at IS NULL", statuses).Find(&tasks).Error; err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		since, err := stageEnteredAt(db, task)
+		if err != nil {
+			return err
+		}
+		if since.IsZero() {
+			continue
+		}
+
+		for _, rule := range rules.RulesFor(task.Status) {
+			if !rules.Due(rule, since, now) {
+				continue
+			}
+			if err := escalateTask(ctx, db, task, rule, since, now); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+func stageEnteredAt(db *gorm.DB, task model.Task) (time.Time, error) {
+	actions := []string{"reassign"}
+	for _, t := range workflow.Current.Transitions {
+		if t.To == task.Status {
+			actions = append(actions, t.Action)
+		}
+	}
+
+	var history model.TaskHistory
+	err := db.
+		Where("task_id = ? AND action IN ?", task.ID, actions).
+		Order("created_at DESC, id DESC").
+		Limit(1).
+		Find(&history).Error
+	return history.CreatedAt, err
+}
+
+func escalateTask(ctx context.Context, db *gorm.DB, task model.Task, rule escalation.Rule, since, now time.Time) error {
+	note := fmt.Sprintf("Task has been in '%s' since %s (rule '%s')", task.Status, since.Format("2006-01-02 15:04"), rule.Name)
+	claimed := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Task{}).
+			Where("id = ? AND status = ? AND escalated_at IS NULL", task.ID, task.Status).
+			Updates(map[string]interface{}{
+				"escalated_at":    now,
+				"escalation_rule": rule.Name,
+				"version":         gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		claimed = true
+		task.Version++
+
+		return tx.Create(&model.TaskHistory{
+			TaskID: task.ID,
+			Action: "escalate",
+			Note:   note,
+		}).Error
+	})
+	if err != nil || !claimed {
+		return err
+	}
+
+	var recipients []uint
+	if len(rule.NotifyRoles) > 0 {
+		if err := db.Model(&model.User{}).
+			Where("role IN ? AND active = ?", rule.NotifyRoles, true).
+			Pluck("id", &recipients).Error; err != nil {
+			return err
+		}
+	}
+
+	notify.Publish(ctx, notify.Event{
+		Type:       "escalate",
+		Task:       task,
+		Note:       note,
+		Recipients: recipients,
+		OccurredAt: now,
+	})
+	return nil
+}
@@ -0,0 +1,95 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/config"
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/scheduler"
+	"github.com/ardhia137/task_todo/src/webhook"
+	"gorm.io/gorm"
+)
+
+const webhookBatchSize = 50
+
+func WebhookJob(db *gorm.DB) scheduler.Job {
+	return scheduler.Job{
+		Name:     "webhook",
+		Interval: config.Current.Webhook.Interval,
+		Run: func(ctx context.Context) error {
+			return DeliverWebhooks(ctx, db, time.Now())
+		},
+	}
+}
+
+func DeliverWebhooks(ctx context.Context, db *gorm.DB, now time.Time) error {
+	db = db.WithContext(ctx)
+	maxAttempts := config.Current.Webhook.MaxAttempts
+	baseDelay := config.Current.Webhook.RetryBackoff
+
+	var pending []model.WebhookDelivery
+	if err := db.
+		Preload("Webhook").
+		Where("delivered_at IS NULL AND failed_at IS NULL AND next_attempt_at <= ?", now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(webhookBatchSize).
+		Find(&pending).Error; err != nil {
+		return err
+	}
+
+	for _, delivery := range pending {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		result := db.Model(&model.WebhookDelivery{}).
+			Where("id = ? AND attempts = ? AND delivered_at IS NULL", delivery.ID, delivery.Attempts).
+			Updates(map[string]interface{}{
+				"attempts":        delivery.Attempts + 1,
+				"next_attempt_at": now.Add(baseDelay),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		attempts := delivery.Attempts + 1
+
+		var sent webhook.Result
+		var sendErr error
+		if delivery.Webhook.Active {
+			sent, sendErr = webhook.Send(ctx, delivery.Webhook, delivery)
+		} else {
+			sendErr = errors.New("webhook is disabled")
+			attempts = maxAttempts
+		}
+
+		updates := map[string]interface{}{
+			"response_status": sent.Status,
+			"response_body":   sent.Body,
+			"duration_ms":     sent.Duration.Milliseconds(),
+		}
+		if sendErr == nil {
+			updates["delivered_at"] = time.Now()
+			updates["last_error"] = ""
+		} else {
+			slog.Warn("webhook delivery failed", "delivery_id", delivery.ID, "url", delivery.Webhook.URL, "attempt", attempts, "max_attempts", maxAttempts, "error", sendErr)
+			updates["last_error"] = sendErr.Error()
+			if attempts >= maxAttempts {
+				updates["failed_at"] = time.Now()
+			} else {
+				updates["next_attempt_at"] = now.Add(retryBackoff(baseDelay, attempts))
+			}
+		}
+
+		if err := db.Model(&model.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
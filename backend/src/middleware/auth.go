@@ -1,14 +1,29 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/ardhia137/task_todo/src/auth"
+	"github.com/ardhia137/task_todo/src/logging"
+	"github.com/ardhia137/task_todo/src/repository"
 	"github.com/ardhia137/task_todo/src/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+func TokenFromQuery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		c.Next()
+	}
+}
+
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -32,16 +47,44 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			c.Set("user_id", claims["user_id"])
-			c.Set("username", claims["username"])
-			c.Set("role", claims["role"])
-		} else {
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok || !token.Valid {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			c.Abort()
+			return
+		}
+
+		userID, userOK := claims["user_id"].(float64)
+		sessionID, sessionOK := claims["sid"].(float64)
+		if !userOK || !sessionOK {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
 			c.Abort()
 			return
 		}
 
+		if err := auth.ValidateSession(c.Request.Context(), repository.Default.Sessions, uint(sessionID), uint(userID)); err != nil {
+			switch {
+			case errors.Is(err, auth.ErrSessionRevoked):
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked or has expired"})
+			case errors.Is(err, auth.ErrUserInactive):
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Account is deactivated"})
+			default:
+				logging.FromContext(c.Request.Context()).Error("failed to validate session", "error", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate session"})
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set("user_id", claims["user_id"])
+		c.Set("username", claims["username"])
+		c.Set("role", claims["role"])
+		c.Set("session_id", claims["sid"])
+		c.Request = c.Request.WithContext(logging.With(c.Request.Context(),
+			"user_id", uint(userID),
+			"role", claims["role"],
+		))
+
 		c.Next()
 	}
 }
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type user struct {
+	ID       uint   `gorm:"primaryKey"`
+	Username string `gorm:"unique;not null"`
+	Password string `gorm:"not null"`
+	Role     string `gorm:"type:enum('pelaksana', 'leader', 'manager');default:'pelaksana';not null"`
+}
+
+func (user) TableName() string { return "users" }
+
+type task struct {
+	ID             uint          `gorm:"primaryKey"`
+	Title          string        `gorm:"not null"`
+	Description    string        `gorm:"type:text"`
+	CreatedBy      uint          `gorm:"not null"`
+	CreatedByUser  user          `gorm:"foreignKey:CreatedBy"`
+	AssignedLeader uint          ``
+	LeaderUser     user          `gorm:"foreignKey:AssignedLeader"`
+	Status         string        `gorm:"type:enum('Submitted', 'Revision', 'Approved by Leader', 'In Progress', 'Completed');default:'Submitted';not null"`
+	Progress       int           `gorm:"default:0;not null"`
+	ProgressBy     uint          ``
+	ProgressUser   user          `gorm:"foreignKey:ProgressBy"`
+	Deadline       time.Time     ``
+	TaskHistories  []taskHistory `gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (task) TableName() string { return "tasks" }
+
+type taskHistory struct {
+	ID         uint   `gorm:"primaryKey"`
+	TaskID     uint   `gorm:"not null;index"`
+	ActionBy   uint   `gorm:"not null"`
+	ActionUser user   `gorm:"foreignKey:ActionBy"`
+	Action     string `gorm:"type:enum('submit', 'revision', 'approve', 'update_progress', 'complete');not null"`
+	Note       string `gorm:"type:text"`
+	CreatedAt  time.Time
+}
+
+func (taskHistory) TableName() string { return "task_histories" }
+
+var baselineTables = []interface{}{
+	&user{},
+	&task{},
+	&taskHistory{},
+}
+
+func init() {
+	register(Migration{
+		Version: 1,
+		Name:    "baseline",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(baselineTables...)
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropTables(tx, baselineTables...)
+		},
+	})
+}
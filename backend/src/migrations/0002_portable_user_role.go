@@ -0,0 +1,39 @@
+package migrations
+
+import "gorm.io/gorm"
+
+type roleCheckedUser struct {
+	ID   uint   `gorm:"primaryKey"`
+	Role string `gorm:"size:20;default:'pelaksana';not null;check:chk_users_role,role IN ('pelaksana','leader','manager')"`
+}
+
+func (roleCheckedUser) TableName() string { return "users" }
+
+func init() {
+	register(Migration{
+		Version: 2,
+		Name:    "portable_user_role",
+		Up: func(tx *gorm.DB) error {
+			if tx.Dialector.Name() == "mysql" {
+				if err := tx.Exec("ALTER TABLE users MODIFY COLUMN role VARCHAR(20) NOT NULL DEFAULT 'pelaksana'").Error; err != nil {
+					return err
+				}
+			}
+			if tx.Migrator().HasConstraint(&roleCheckedUser{}, "chk_users_role") {
+				return nil
+			}
+			return tx.Migrator().CreateConstraint(&roleCheckedUser{}, "chk_users_role")
+		},
+		Down: func(tx *gorm.DB) error {
+			if tx.Migrator().HasConstraint(&roleCheckedUser{}, "chk_users_role") {
+				if err := tx.Migrator().DropConstraint(&roleCheckedUser{}, "chk_users_role"); err != nil {
+					return err
+				}
+			}
+			if tx.Dialector.Name() == "mysql" {
+				return tx.Exec("ALTER TABLE users MODIFY COLUMN role ENUM('pelaksana', 'leader', 'manager') NOT NULL DEFAULT 'pelaksana'").Error
+			}
+			return nil
+		},
+	})
+}
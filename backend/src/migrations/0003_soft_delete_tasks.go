@@ -0,0 +1,39 @@
+package migrations
+
+import "gorm.io/gorm"
+
+type softDeletedTask struct {
+	ID        uint           `gorm:"primaryKey"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (softDeletedTask) TableName() string { return "tasks" }
+
+func init() {
+	register(Migration{
+		Version: 3,
+		Name:    "soft_delete_tasks",
+		Up: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&softDeletedTask{}, "DeletedAt") {
+				if err := tx.Migrator().AddColumn(&softDeletedTask{}, "DeletedAt"); err != nil {
+					return err
+				}
+			}
+			if tx.Migrator().HasIndex(&softDeletedTask{}, "DeletedAt") {
+				return nil
+			}
+			return tx.Migrator().CreateIndex(&softDeletedTask{}, "DeletedAt")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Exec("DELETE FROM tasks WHERE deleted_at IS NOT NULL").Error; err != nil {
+				return err
+			}
+			if tx.Migrator().HasIndex(&softDeletedTask{}, "DeletedAt") {
+				if err := tx.Migrator().DropIndex(&softDeletedTask{}, "DeletedAt"); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().DropColumn(&softDeletedTask{}, "DeletedAt")
+		},
+	})
+}
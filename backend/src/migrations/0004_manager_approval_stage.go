@@ -0,0 +1,47 @@
+package migrations
+
+import "gorm.io/gorm"
+
+type managerApprovalTask struct {
+	ID     uint   `gorm:"primaryKey"`
+	Status string `gorm:"type:enum('Submitted', 'Revision', 'Approved by Leader', 'Returned to Leader', 'Approved by Manager', 'Rejected', 'In Progress', 'Completed');default:'Submitted';not null"`
+}
+
+func (managerApprovalTask) TableName() string { return "tasks" }
+
+type managerApprovalHistory struct {
+	ID     uint   `gorm:"primaryKey"`
+	Action string `gorm:"type:enum('submit', 'revision', 'approve', 'manager_approve', 'return', 'reject', 'update_progress', 'complete');not null"`
+}
+
+func (managerApprovalHistory) TableName() string { return "task_histories" }
+
+func alterEnumColumn(tx *gorm.DB, model interface{}, field, column string) error {
+	if tx.Dialector.Name() != "mysql" {
+		return nil
+	}
+	enum, err := isEnumColumn(tx, model, column)
+	if err != nil || !enum {
+		return err
+	}
+	return tx.Migrator().AlterColumn(model, field)
+}
+
+func init() {
+	register(Migration{
+		Version: 4,
+		Name:    "manager_approval_stage",
+		Up: func(tx *gorm.DB) error {
+			if err := alterEnumColumn(tx, &managerApprovalTask{}, "Status", "status"); err != nil {
+				return err
+			}
+			return alterEnumColumn(tx, &managerApprovalHistory{}, "Action", "action")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := alterEnumColumn(tx, &taskHistory{}, "Action", "action"); err != nil {
+				return err
+			}
+			return alterEnumColumn(tx, &task{}, "Status", "status")
+		},
+	})
+}
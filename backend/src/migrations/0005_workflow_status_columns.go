@@ -0,0 +1,61 @@
+package migrations
+
+import "gorm.io/gorm"
+
+type workflowTask struct {
+	ID     uint   `gorm:"primaryKey"`
+	Status string `gorm:"size:50;not null;index"`
+}
+
+func (workflowTask) TableName() string { return "tasks" }
+
+type workflowHistory struct {
+	ID     uint   `gorm:"primaryKey"`
+	Action string `gorm:"size:50;not null"`
+}
+
+func (workflowHistory) TableName() string { return "task_histories" }
+
+func init() {
+	register(Migration{
+		Version: 5,
+		Name:    "workflow_status_columns",
+		Up: func(tx *gorm.DB) error {
+			switch tx.Dialector.Name() {
+			case "mysql":
+				if err := tx.Migrator().AlterColumn(&workflowTask{}, "Status"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().AlterColumn(&workflowHistory{}, "Action"); err != nil {
+					return err
+				}
+			case "postgres":
+				if err := tx.Exec("ALTER TABLE tasks ALTER COLUMN status TYPE VARCHAR(50), ALTER COLUMN status DROP DEFAULT").Error; err != nil {
+					return err
+				}
+				if err := tx.Exec("ALTER TABLE task_histories ALTER COLUMN action TYPE VARCHAR(50)").Error; err != nil {
+					return err
+				}
+			}
+			return createIndexes(tx, &workflowTask{}, "Status")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropIndexes(tx, &workflowTask{}, "Status"); err != nil {
+				return err
+			}
+			switch tx.Dialector.Name() {
+			case "mysql":
+				if err := tx.Migrator().AlterColumn(&managerApprovalTask{}, "Status"); err != nil {
+					return err
+				}
+				return tx.Migrator().AlterColumn(&managerApprovalHistory{}, "Action")
+			case "postgres":
+				if err := tx.Exec("ALTER TABLE tasks ALTER COLUMN status TYPE VARCHAR(20), ALTER COLUMN status SET DEFAULT 'Submitted'").Error; err != nil {
+					return err
+				}
+				return tx.Exec("ALTER TABLE task_histories ALTER COLUMN action TYPE VARCHAR(20)").Error
+			}
+			return nil
+		},
+	})
+}
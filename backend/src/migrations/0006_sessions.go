@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type session struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	User      user      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+func (session) TableName() string { return "sessions" }
+
+type refreshToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	SessionID uint      `gorm:"not null;index"`
+	Session   session   `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE;"`
+	TokenHash string    `gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (refreshToken) TableName() string { return "refresh_tokens" }
+
+func init() {
+	register(Migration{
+		Version: 6,
+		Name:    "sessions",
+		Up: func(tx *gorm.DB) error {
+			return createTables(tx, &session{}, &refreshToken{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropTables(tx, &session{}, &refreshToken{})
+		},
+	})
+}
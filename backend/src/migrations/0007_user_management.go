@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type managedUser struct {
+	ID        uint `gorm:"primaryKey"`
+	Active    bool `gorm:"default:true;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (managedUser) TableName() string { return "users" }
+
+type userAudit struct {
+	ID         uint   `gorm:"primaryKey"`
+	UserID     uint   `gorm:"not null;index"`
+	ActionBy   uint   `gorm:"not null"`
+	ActionUser user   `gorm:"foreignKey:ActionBy"`
+	Action     string `gorm:"size:50;not null"`
+	Changes    string `gorm:"type:text"`
+	CreatedAt  time.Time
+}
+
+func (userAudit) TableName() string { return "user_audits" }
+
+func init() {
+	register(Migration{
+		Version: 7,
+		Name:    "user_management",
+		Up: func(tx *gorm.DB) error {
+			if err := addColumns(tx, &managedUser{}, "Active", "CreatedAt", "UpdatedAt"); err != nil {
+				return err
+			}
+			return createTables(tx, &userAudit{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(tx, &userAudit{}); err != nil {
+				return err
+			}
+			return dropColumns(tx, &managedUser{}, "UpdatedAt", "CreatedAt", "Active")
+		},
+	})
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type passwordResetToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	User      user      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	TokenHash string    `gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedBy uint `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+func (passwordResetToken) TableName() string { return "password_reset_tokens" }
+
+func init() {
+	register(Migration{
+		Version: 8,
+		Name:    "password_reset_tokens",
+		Up: func(tx *gorm.DB) error {
+			return createTables(tx, &passwordResetToken{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropTables(tx, &passwordResetToken{})
+		},
+	})
+}
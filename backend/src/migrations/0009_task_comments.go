@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type taskComment struct {
+	ID        uint          `gorm:"primaryKey"`
+	TaskID    uint          `gorm:"not null;index"`
+	Task      task          `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE;"`
+	ParentID  *uint         `gorm:"index"`
+	AuthorID  uint          `gorm:"not null"`
+	Author    user          `gorm:"foreignKey:AuthorID"`
+	Body      string        `gorm:"type:text;not null"`
+	Mentions  []user        `gorm:"many2many:task_comment_mentions"`
+	Replies   []taskComment `gorm:"foreignKey:ParentID"`
+	EditedAt  *time.Time
+	DeletedAt *time.Time
+	CreatedAt time.Time
+}
+
+func (taskComment) TableName() string { return "task_comments" }
+
+type taskCommentMention struct {
+	TaskCommentID uint        `gorm:"primaryKey"`
+	TaskComment   taskComment `gorm:"foreignKey:TaskCommentID"`
+	UserID        uint        `gorm:"primaryKey"`
+	User          user        `gorm:"foreignKey:UserID"`
+}
+
+func (taskCommentMention) TableName() string { return "task_comment_mentions" }
+
+func init() {
+	register(Migration{
+		Version: 9,
+		Name:    "task_comments",
+		Up: func(tx *gorm.DB) error {
+			return createTables(tx, &taskComment{}, &taskCommentMention{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropTables(tx, &taskComment{}, &taskCommentMention{})
+		},
+	})
+}
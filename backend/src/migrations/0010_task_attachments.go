@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type taskAttachment struct {
+	ID          uint   `gorm:"primaryKey"`
+	TaskID      uint   `gorm:"not null;index"`
+	Task        task   `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE;"`
+	UploadedBy  uint   `gorm:"not null"`
+	Uploader    user   `gorm:"foreignKey:UploadedBy"`
+	FileName    string `gorm:"size:255;not null"`
+	ContentType string `gorm:"size:100;not null"`
+	Size        int64  `gorm:"not null"`
+	StorageKey  string `gorm:"size:255;uniqueIndex;not null"`
+	CreatedAt   time.Time
+}
+
+func (taskAttachment) TableName() string { return "task_attachments" }
+
+func init() {
+	register(Migration{
+		Version: 10,
+		Name:    "task_attachments",
+		Up: func(tx *gorm.DB) error {
+			return createTables(tx, &taskAttachment{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropTables(tx, &taskAttachment{})
+		},
+	})
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type deadlineTask struct {
+	ID             uint `gorm:"primaryKey"`
+	ReminderSentAt *time.Time
+	OverdueAt      *time.Time
+}
+
+func (deadlineTask) TableName() string { return "tasks" }
+
+type systemActionHistory struct {
+	ID       uint  `gorm:"primaryKey"`
+	ActionBy *uint ``
+}
+
+func (systemActionHistory) TableName() string { return "task_histories" }
+
+func init() {
+	register(Migration{
+		Version: 11,
+		Name:    "deadline_tracking",
+		Up: func(tx *gorm.DB) error {
+			if err := addColumns(tx, &deadlineTask{}, "ReminderSentAt", "OverdueAt"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().AlterColumn(&systemActionHistory{}, "ActionBy"); err != nil {
+				return err
+			}
+			return createIndexes(tx, &taskHistory{}, "TaskID")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Exec("DELETE FROM task_histories WHERE action_by IS NULL").Error; err != nil {
+				return err
+			}
+			if err := tx.Migrator().AlterColumn(&taskHistory{}, "ActionBy"); err != nil {
+				return err
+			}
+			if err := createIndexes(tx, &taskHistory{}, "TaskID"); err != nil {
+				return err
+			}
+			return dropColumns(tx, &deadlineTask{}, "OverdueAt", "ReminderSentAt")
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type escalatedTask struct {
+	ID             uint       `gorm:"primaryKey"`
+	EscalatedAt    *time.Time `gorm:"index"`
+	EscalationRule string     `gorm:"size:100"`
+}
+
+func (escalatedTask) TableName() string { return "tasks" }
+
+func init() {
+	register(Migration{
+		Version: 12,
+		Name:    "task_escalation",
+		Up: func(tx *gorm.DB) error {
+			if err := addColumns(tx, &escalatedTask{}, "EscalatedAt", "EscalationRule"); err != nil {
+				return err
+			}
+			return createIndexes(tx, &escalatedTask{}, "EscalatedAt")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropIndexes(tx, &escalatedTask{}, "EscalatedAt"); err != nil {
+				return err
+			}
+			return dropColumns(tx, &escalatedTask{}, "EscalationRule", "EscalatedAt")
+		},
+	})
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type emailUser struct {
+	ID    uint   `gorm:"primaryKey"`
+	Email string `gorm:"size:255;index"`
+}
+
+func (emailUser) TableName() string { return "users" }
+
+type emailOutbox struct {
+	ID            uint       `gorm:"primaryKey"`
+	Recipient     string     `gorm:"size:255;not null"`
+	Subject       string     `gorm:"size:255;not null"`
+	Body          string     `gorm:"type:text;not null"`
+	EventType     string     `gorm:"size:50"`
+	TaskID        *uint      `gorm:"index"`
+	Attempts      int        `gorm:"default:0;not null"`
+	NextAttemptAt time.Time  `gorm:"not null;index"`
+	LastError     string     `gorm:"type:text"`
+	SentAt        *time.Time `gorm:"index"`
+	FailedAt      *time.Time
+	CreatedAt     time.Time
+}
+
+func (emailOutbox) TableName() string { return "email_outbox" }
+
+func init() {
+	register(Migration{
+		Version: 13,
+		Name:    "email_outbox",
+		Up: func(tx *gorm.DB) error {
+			if err := addColumns(tx, &emailUser{}, "Email"); err != nil {
+				return err
+			}
+			if err := createIndexes(tx, &emailUser{}, "Email"); err != nil {
+				return err
+			}
+			return createTables(tx, &emailOutbox{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropTables(tx, &emailOutbox{}); err != nil {
+				return err
+			}
+			if err := dropIndexes(tx, &emailUser{}, "Email"); err != nil {
+				return err
+			}
+			return dropColumns(tx, &emailUser{}, "Email")
+		},
+	})
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type notification struct {
+	ID        uint       `gorm:"primaryKey"`
+	UserID    uint       `gorm:"not null;index:idx_notifications_user_read"`
+	User      user       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	TaskID    *uint      `gorm:"index"`
+	ActorID   *uint      ``
+	Actor     *user      `gorm:"foreignKey:ActorID"`
+	Type      string     `gorm:"size:50;not null"`
+	Message   string     `gorm:"size:255;not null"`
+	Note      string     `gorm:"type:text"`
+	ReadAt    *time.Time `gorm:"index:idx_notifications_user_read"`
+	CreatedAt time.Time  `gorm:"index"`
+}
+
+func (notification) TableName() string { return "notifications" }
+
+func init() {
+	register(Migration{
+		Version: 14,
+		Name:    "notifications",
+		Up: func(tx *gorm.DB) error {
+			return createTables(tx, &notification{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropTables(tx, &notification{})
+		},
+	})
+}
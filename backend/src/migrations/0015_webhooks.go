@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type webhook struct {
+	ID          uint   `gorm:"primaryKey"`
+	URL         string `gorm:"size:2048;not null"`
+	Secret      string `gorm:"size:128;not null"`
+	Events      string `gorm:"type:text"`
+	Description string `gorm:"size:255"`
+	Active      bool   `gorm:"default:true;not null"`
+	CreatedBy   uint   `gorm:"not null"`
+	Creator     user   `gorm:"foreignKey:CreatedBy"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (webhook) TableName() string { return "webhooks" }
+
+type webhookDelivery struct {
+	ID             uint      `gorm:"primaryKey"`
+	WebhookID      uint      `gorm:"not null;index"`
+	Webhook        webhook   `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE;"`
+	EventID        string    `gorm:"size:64;not null;index"`
+	EventType      string    `gorm:"size:50;not null"`
+	Payload        string    `gorm:"type:text;not null"`
+	Attempts       int       `gorm:"default:0;not null"`
+	NextAttemptAt  time.Time `gorm:"not null;index"`
+	ResponseStatus int
+	ResponseBody   string `gorm:"type:text"`
+	LastError      string `gorm:"type:text"`
+	DurationMs     int64
+	DeliveredAt    *time.Time `gorm:"index"`
+	FailedAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (webhookDelivery) TableName() string { return "webhook_deliveries" }
+
+func init() {
+	register(Migration{
+		Version: 15,
+		Name:    "webhooks",
+		Up: func(tx *gorm.DB) error {
+			return createTables(tx, &webhook{}, &webhookDelivery{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropTables(tx, &webhook{}, &webhookDelivery{})
+		},
+	})
+}
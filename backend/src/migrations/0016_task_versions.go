@@ -0,0 +1,23 @@
+package migrations
+
+import "gorm.io/gorm"
+
+type versionedTask struct {
+	ID      uint `gorm:"primaryKey"`
+	Version uint `gorm:"default:1;not null"`
+}
+
+func (versionedTask) TableName() string { return "tasks" }
+
+func init() {
+	register(Migration{
+		Version: 16,
+		Name:    "task_versions",
+		Up: func(tx *gorm.DB) error {
+			return addColumns(tx, &versionedTask{}, "Version")
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &versionedTask{}, "Version")
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import "gorm.io/gorm"
+
+type taskHistoryChange struct {
+	ID        uint   `gorm:"primaryKey"`
+	HistoryID uint   `gorm:"not null;index"`
+	Field     string `gorm:"size:50;not null;index"`
+	OldValue  string `gorm:"type:text"`
+	NewValue  string `gorm:"type:text"`
+}
+
+func (taskHistoryChange) TableName() string { return "task_history_changes" }
+
+type changeTrackedHistory struct {
+	ID      uint                `gorm:"primaryKey"`
+	Changes []taskHistoryChange `gorm:"foreignKey:HistoryID;constraint:OnDelete:CASCADE;"`
+}
+
+func (changeTrackedHistory) TableName() string { return "task_histories" }
+
+func init() {
+	register(Migration{
+		Version: 17,
+		Name:    "task_history_changes",
+		Up: func(tx *gorm.DB) error {
+			if err := createTables(tx, &taskHistoryChange{}); err != nil {
+				return err
+			}
+			if !tx.Migrator().HasConstraint(&changeTrackedHistory{}, "Changes") {
+				if err := tx.Migrator().CreateConstraint(&changeTrackedHistory{}, "Changes"); err != nil {
+					return err
+				}
+			}
+			return createIndexes(tx, &taskHistoryChange{}, "HistoryID", "Field")
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropTables(tx, &taskHistoryChange{})
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const minEnumWidth = 20
+
+type portableDialector struct {
+	gorm.Dialector
+}
+
+func portable(tx *gorm.DB) *gorm.DB {
+	if tx.Dialector.Name() == "mysql" {
+		return tx
+	}
+
+	config := *tx.Config
+	config.Dialector = portableDialector{tx.Dialector}
+
+	session := tx.Session(&gorm.Session{})
+	session.Config = &config
+	return session
+}
+
+func (d portableDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	switch m := d.Dialector.Migrator(db).(type) {
+	case sqlite.Migrator:
+		m.Dialector = d
+		return m
+	case postgres.Migrator:
+		m.Dialector = d
+		return m
+	default:
+		return m
+	}
+}
+
+func (d portableDialector) DataTypeOf(field *schema.Field) string {
+	dataType := string(field.DataType)
+	if values, ok := strings.CutPrefix(strings.ToLower(dataType), "enum("); ok {
+		width := minEnumWidth
+		for _, value := range strings.Split(strings.TrimSuffix(values, ")"), ",") {
+			width = max(width, len(strings.Trim(strings.TrimSpace(value), "'")))
+		}
+		return fmt.Sprintf("varchar(%d)", width)
+	}
+	return d.Dialector.DataTypeOf(field)
+}
+
+func (d portableDialector) SavePoint(tx *gorm.DB, name string) error {
+	if savePointer, ok := d.Dialector.(gorm.SavePointerDialectorInterface); ok {
+		return savePointer.SavePoint(tx, name)
+	}
+	return gorm.ErrUnsupportedDriver
+}
+
+func (d portableDialector) RollbackTo(tx *gorm.DB, name string) error {
+	if savePointer, ok := d.Dialector.(gorm.SavePointerDialectorInterface); ok {
+		return savePointer.RollbackTo(tx, name)
+	}
+	return gorm.ErrUnsupportedDriver
+}
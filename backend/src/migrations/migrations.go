@@ -0,0 +1,275 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSchemaBehind     = errors.New("database schema is behind")
+	ErrNothingToRevert  = errors.New("no applied migrations to revert")
+	ErrUnknownMigration = errors.New("applied migration is not known to this binary")
+)
+
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+type SchemaMigration struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"size:255;not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Status struct {
+	Version   uint
+	Name      string
+	AppliedAt *time.Time
+}
+
+var registry []Migration
+
+func register(m Migration) {
+	registry = append(registry, m)
+	sort.Slice(registry, func(i, j int) bool { return registry[i].Version < registry[j].Version })
+}
+
+func All() []Migration {
+	return append([]Migration(nil), registry...)
+}
+
+func applied(db *gorm.DB) (map[uint]SchemaMigration, error) {
+	if !db.Migrator().HasTable(&SchemaMigration{}) {
+		if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+			return nil, fmt.Errorf("prepare schema_migrations: %w", err)
+		}
+	}
+	return appliedRows(db)
+}
+
+func appliedRows(db *gorm.DB) (map[uint]SchemaMigration, error) {
+	var rows []SchemaMigration
+	if err := db.Order("version ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	versions := make(map[uint]SchemaMigration, len(rows))
+	for _, row := range rows {
+		versions[row.Version] = row
+	}
+	return versions, nil
+}
+
+func Pending(db *gorm.DB) ([]Migration, error) {
+	done, err := applied(db)
+	if err != nil {
+		return nil, err
+	}
+	return pendingFrom(done), nil
+}
+
+func pendingFrom(done map[uint]SchemaMigration) []Migration {
+	var pending []Migration
+	for _, m := range registry {
+		if _, ok := done[m.Version]; !ok {
+			pending = append(pending, m)
+		}
+	}
+	return pending
+}
+
+func Up(db *gorm.DB) ([]Migration, error) {
+	pending, err := Pending(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []Migration
+	for _, m := range pending {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(portable(tx)); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return ran, fmt.Errorf("migration %d %s: %w", m.Version, m.Name, err)
+		}
+		ran = append(ran, m)
+	}
+	return ran, nil
+}
+
+func Down(db *gorm.DB) (Migration, error) {
+	if _, err := applied(db); err != nil {
+		return Migration{}, err
+	}
+
+	var last SchemaMigration
+	if err := db.Order("version DESC").Limit(1).Find(&last).Error; err != nil {
+		return Migration{}, err
+	}
+	if last.Version == 0 {
+		return Migration{}, ErrNothingToRevert
+	}
+
+	var target *Migration
+	for i := range registry {
+		if registry[i].Version == last.Version {
+			target = &registry[i]
+		}
+	}
+	if target == nil {
+		return Migration{}, fmt.Errorf("%w: %d %s", ErrUnknownMigration, last.Version, last.Name)
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := target.Down(portable(tx)); err != nil {
+			return err
+		}
+		return tx.Delete(&SchemaMigration{}, target.Version).Error
+	})
+	if err != nil {
+		return Migration{}, fmt.Errorf("revert migration %d %s: %w", target.Version, target.Name, err)
+	}
+	return *target, nil
+}
+
+func List(db *gorm.DB) ([]Status, error) {
+	done, err := applied(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+	known := map[uint]bool{}
+	for _, m := range registry {
+		known[m.Version] = true
+		status := Status{Version: m.Version, Name: m.Name}
+		if row, ok := done[m.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	for version, row := range done {
+		if !known[version] {
+			appliedAt := row.AppliedAt
+			statuses = append(statuses, Status{Version: version, Name: row.Name + " (unknown)", AppliedAt: &appliedAt})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+func Check(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&SchemaMigration{}) {
+		return fmt.Errorf("%w: schema_migrations table is missing", ErrSchemaBehind)
+	}
+
+	done, err := appliedRows(db)
+	if err != nil {
+		return err
+	}
+
+	pending := pendingFrom(done)
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d pending migration(s), next is %d %s", ErrSchemaBehind, len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
+
+func addColumns(tx *gorm.DB, model interface{}, fields ...string) error {
+	for _, field := range fields {
+		if tx.Migrator().HasColumn(model, field) {
+			continue
+		}
+		if err := tx.Migrator().AddColumn(model, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dropColumns(tx *gorm.DB, model interface{}, fields ...string) error {
+	for _, field := range fields {
+		if !tx.Migrator().HasColumn(model, field) {
+			continue
+		}
+		if err := tx.Migrator().DropColumn(model, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createIndexes(tx *gorm.DB, model interface{}, fields ...string) error {
+	for _, field := range fields {
+		if tx.Migrator().HasIndex(model, field) {
+			continue
+		}
+		if err := tx.Migrator().CreateIndex(model, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dropIndexes(tx *gorm.DB, model interface{}, fields ...string) error {
+	for _, field := range fields {
+		if !tx.Migrator().HasIndex(model, field) {
+			continue
+		}
+		if err := tx.Migrator().DropIndex(model, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createTables(tx *gorm.DB, tables ...interface{}) error {
+	for _, table := range tables {
+		if tx.Migrator().HasTable(table) {
+			continue
+		}
+		if err := tx.Migrator().CreateTable(table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dropTables(tx *gorm.DB, tables ...interface{}) error {
+	for i := len(tables) - 1; i >= 0; i-- {
+		if err := tx.Migrator().DropTable(tables[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isEnumColumn(tx *gorm.DB, model interface{}, column string) (bool, error) {
+	columns, err := tx.Migrator().ColumnTypes(model)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range columns {
+		if c.Name() == column {
+			return strings.EqualFold(c.DatabaseTypeName(), "enum"), nil
+		}
+	}
+	return false, nil
+}
@@ -6,13 +6,123 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Token string `json:"token"`
-	Role  string `json:"role"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	Role         string `json:"role"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 type TaskRequest struct {
 	Title       string `json:"title" binding:"required"`
 	Description string `json:"description"`
 	AssigneeID  uint   `json:"assignee_id" binding:"required"`
-	DueDate     string `json:"due_date"`
+	DueDate     string `json:"due_date" binding:"required"`
+}
+
+type ProgressRequest struct {
+	Progress *int   `json:"progress" binding:"required,min=0,max=100"`
+	Note     string `json:"note"`
+}
+
+type TransitionRequest struct {
+	Action string `json:"action" binding:"required"`
+	Note   string `json:"note"`
+}
+
+type CreateUserRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Email    string `json:"email" binding:"omitempty,email"`
+	Role     string `json:"role" binding:"required,oneof=pelaksana leader manager"`
+}
+
+type UpdateUserRequest struct {
+	Username string  `json:"username" binding:"required"`
+	Email    *string `json:"email" binding:"omitempty,email"`
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=pelaksana leader manager"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+type TaskListQuery struct {
+	Page         int      `form:"page"`
+	PageSize     int      `form:"page_size"`
+	Status       []string `form:"status"`
+	LeaderID     uint     `form:"leader_id"`
+	CreatedBy    uint     `form:"created_by"`
+	DeadlineFrom string   `form:"deadline_from"`
+	DeadlineTo   string   `form:"deadline_to"`
+	ProgressMin  *int     `form:"progress_min"`
+	ProgressMax  *int     `form:"progress_max"`
+	Overdue      *bool    `form:"overdue"`
+	Escalated    *bool    `form:"escalated"`
+	Search       string   `form:"q"`
+	Sort         string   `form:"sort"`
+}
+
+type ReassignRequest struct {
+	LeaderID uint   `json:"leader_id" binding:"required"`
+	Note     string `json:"note"`
+}
+
+type Pagination struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type HistoryListQuery struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+	Order    string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Field    string `form:"field"`
+}
+
+type CommentRequest struct {
+	Body     string `json:"body" binding:"required"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+type CommentListQuery struct {
+	Page     int `form:"page"`
+	PageSize int `form:"page_size"`
+}
+
+type NotificationListQuery struct {
+	Page     int   `form:"page"`
+	PageSize int   `form:"page_size"`
+	Unread   *bool `form:"unread"`
+}
+
+type WebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url"`
+	Events      []string `json:"events" binding:"required,min=1,dive,required"`
+	Description string   `json:"description" binding:"max=255"`
+	Active      *bool    `json:"active"`
+}
+
+type DeliveryListQuery struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+	Status   string `form:"status" binding:"omitempty,oneof=pending delivered failed"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required"`
 }
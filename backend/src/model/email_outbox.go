@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type EmailOutbox struct {
+	ID            uint       `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	Recipient     string     `gorm:"size:255;not null" json:"recipient"`
+	Subject       string     `gorm:"size:255;not null" json:"subject"`
+	Body          string     `gorm:"type:text;not null" json:"body"`
+	EventType     string     `gorm:"size:50" json:"event_type"`
+	TaskID        *uint      `gorm:"index" json:"task_id"`
+	Attempts      int        `gorm:"default:0;not null" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"not null;index" json:"next_attempt_at"`
+	LastError     string     `gorm:"type:text" json:"last_error"`
+	SentAt        *time.Time `gorm:"index" json:"sent_at"`
+	FailedAt      *time.Time `json:"failed_at"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (EmailOutbox) TableName() string {
+	return "email_outbox"
+}
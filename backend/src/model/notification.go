@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type Notification struct {
+	ID        uint       `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	UserID    uint       `gorm:"not null;index:idx_notifications_user_read" json:"-"`
+	User      User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+	TaskID    *uint      `gorm:"index" json:"task_id"`
+	ActorID   *uint      `json:"-"`
+	Actor     *User      `gorm:"foreignKey:ActorID" json:"actor"`
+	Type      string     `gorm:"size:50;not null" json:"type"`
+	Message   string     `gorm:"size:255;not null" json:"message"`
+	Note      string     `gorm:"type:text" json:"note"`
+	ReadAt    *time.Time `gorm:"index:idx_notifications_user_read" json:"read_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime;index" json:"created_at"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	User      User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+	TokenHash string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedBy uint       `gorm:"not null" json:"created_by"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type Session struct {
+	ID        uint       `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	User      User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	SessionID uint       `gorm:"not null;index" json:"session_id"`
+	Session   Session    `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE;" json:"-"`
+	TokenHash string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -1,19 +1,29 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Task struct {
-	ID             uint          `gorm:"primaryKey" json:"id" autoIncrement:"true"`
-	Title          string        `gorm:"not null" json:"title"`
-	Description    string        `gorm:"type:text" json:"description"`
-	CreatedBy      uint          `gorm:"not null" json:"-"`
-	CreatedByUser  User          `gorm:"foreignKey:CreatedBy" json:"created_by"`
-	AssignedLeader uint          `json:"-"`
-	LeaderUser     User          `gorm:"foreignKey:AssignedLeader" json:"assigned_leader"`
-	Status         string        `gorm:"type:enum('Submitted', 'Revision', 'Approved by Leader', 'In Progress', 'Completed');default:'Submitted';not null" json:"status"`
-	Progress       int           `gorm:"default:0;not null" json:"progress"`
-	ProgressBy     uint          `json:"-"`
-	ProgressUser   User          `gorm:"foreignKey:ProgressBy" json:"progress_by"`
-	Deadline       time.Time     `json:"deadline"`
-	TaskHistories  []TaskHistory `gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"histories"`
+	ID             uint           `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	Title          string         `gorm:"not null" json:"title"`
+	Description    string         `gorm:"type:text" json:"description"`
+	CreatedBy      uint           `gorm:"not null" json:"-"`
+	CreatedByUser  User           `gorm:"foreignKey:CreatedBy" json:"created_by"`
+	AssignedLeader uint           `json:"-"`
+	LeaderUser     User           `gorm:"foreignKey:AssignedLeader" json:"assigned_leader"`
+	Status         string         `gorm:"size:50;not null;index" json:"status"`
+	Progress       int            `gorm:"default:0;not null" json:"progress"`
+	ProgressBy     uint           `json:"-"`
+	ProgressUser   User           `gorm:"foreignKey:ProgressBy" json:"progress_by"`
+	Deadline       time.Time      `json:"deadline"`
+	Version        uint           `gorm:"default:1;not null" json:"version"`
+	ReminderSentAt *time.Time     `json:"reminder_sent_at"`
+	OverdueAt      *time.Time     `json:"overdue_at"`
+	EscalatedAt    *time.Time     `gorm:"index" json:"escalated_at"`
+	EscalationRule string         `gorm:"size:100" json:"escalation_rule,omitempty"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+	TaskHistories  []TaskHistory  `gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"histories"`
 }
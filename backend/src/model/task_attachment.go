@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type TaskAttachment struct {
+	ID          uint      `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	TaskID      uint      `gorm:"not null;index" json:"task_id"`
+	Task        Task      `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE;" json:"-"`
+	UploadedBy  uint      `gorm:"not null" json:"-"`
+	Uploader    User      `gorm:"foreignKey:UploadedBy" json:"uploaded_by"`
+	FileName    string    `gorm:"size:255;not null" json:"file_name"`
+	ContentType string    `gorm:"size:100;not null" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`
+	StorageKey  string    `gorm:"size:255;uniqueIndex;not null" json:"-"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
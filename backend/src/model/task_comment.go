@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type TaskComment struct {
+	ID        uint          `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	TaskID    uint          `gorm:"not null;index" json:"task_id"`
+	Task      Task          `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE;" json:"-"`
+	ParentID  *uint         `gorm:"index" json:"parent_id"`
+	AuthorID  uint          `gorm:"not null" json:"-"`
+	Author    User          `gorm:"foreignKey:AuthorID" json:"author"`
+	Body      string        `gorm:"type:text;not null" json:"body"`
+	Mentions  []User        `gorm:"many2many:task_comment_mentions;" json:"mentions"`
+	Replies   []TaskComment `gorm:"foreignKey:ParentID" json:"replies,omitempty"`
+	EditedAt  *time.Time    `json:"edited_at"`
+	DeletedAt *time.Time    `json:"deleted_at"`
+	CreatedAt time.Time     `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -3,11 +3,20 @@ package model
 import "time"
 
 type TaskHistory struct {
-	ID         uint      `gorm:"primaryKey" json:"id" autoIncrement:"true"`
-	TaskID     uint      `gorm:"not null;index" json:"task_id"`
-	ActionBy   uint      `gorm:"not null" json:"-"`
-	ActionUser User      `gorm:"foreignKey:ActionBy" json:"action_by"`
-	Action     string    `gorm:"type:enum('submit', 'revision', 'approve', 'update_progress', 'complete');not null" json:"action"`
-	Note       string    `gorm:"type:text" json:"note"`
-	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ID         uint                `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	TaskID     uint                `gorm:"not null;index" json:"task_id"`
+	ActionBy   *uint               `json:"-"`
+	ActionUser *User               `gorm:"foreignKey:ActionBy" json:"action_by"`
+	Action     string              `gorm:"size:50;not null" json:"action"`
+	Note       string              `gorm:"type:text" json:"note"`
+	Changes    []TaskHistoryChange `gorm:"foreignKey:HistoryID;constraint:OnDelete:CASCADE;" json:"changes,omitempty"`
+	CreatedAt  time.Time           `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type TaskHistoryChange struct {
+	ID        uint   `gorm:"primaryKey" json:"-" autoIncrement:"true"`
+	HistoryID uint   `gorm:"not null;index" json:"-"`
+	Field     string `gorm:"size:50;not null;index" json:"field"`
+	OldValue  string `gorm:"type:text" json:"old"`
+	NewValue  string `gorm:"type:text" json:"new"`
 }
@@ -1,8 +1,14 @@
 package model
 
+import "time"
+
 type User struct {
-	ID       uint   `gorm:"primaryKey" json:"id" autoIncrement:"true"`
-	Username string `gorm:"unique;not null" json:"username"`
-	Password string `gorm:"not null" json:"-"`
-	Role     string `gorm:"type:enum('pelaksana', 'leader', 'manager');default:'pelaksana';not null"`
+	ID        uint      `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	Username  string    `gorm:"unique;not null" json:"username"`
+	Password  string    `gorm:"not null" json:"-"`
+	Email     string    `gorm:"size:255;index" json:"email"`
+	Role      string    `gorm:"size:20;default:'pelaksana';not null;check:chk_users_role,role IN ('pelaksana','leader','manager')"`
+	Active    bool      `gorm:"default:true;not null" json:"active"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type UserAudit struct {
+	ID         uint      `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	UserID     uint      `gorm:"not null;index" json:"user_id"`
+	ActionBy   uint      `gorm:"not null" json:"-"`
+	ActionUser User      `gorm:"foreignKey:ActionBy" json:"action_by"`
+	Action     string    `gorm:"size:50;not null" json:"action"`
+	Changes    string    `gorm:"type:text" json:"changes"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+type Webhook struct {
+	ID          uint      `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	URL         string    `gorm:"size:2048;not null" json:"url"`
+	Secret      string    `gorm:"size:128;not null" json:"-"`
+	Events      []string  `gorm:"serializer:json;type:text" json:"events"`
+	Description string    `gorm:"size:255" json:"description"`
+	Active      bool      `gorm:"not null" json:"active"`
+	CreatedBy   uint      `gorm:"not null" json:"-"`
+	Creator     User      `gorm:"foreignKey:CreatedBy" json:"created_by"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey" json:"id" autoIncrement:"true"`
+	WebhookID      uint       `gorm:"not null;index" json:"webhook_id"`
+	Webhook        Webhook    `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE;" json:"-"`
+	EventID        string     `gorm:"size:64;not null;index" json:"event_id"`
+	EventType      string     `gorm:"size:50;not null" json:"event_type"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Attempts       int        `gorm:"default:0;not null" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null;index" json:"next_attempt_at"`
+	ResponseStatus int        `json:"response_status"`
+	ResponseBody   string     `gorm:"type:text" json:"response_body"`
+	LastError      string     `gorm:"type:text" json:"last_error"`
+	DurationMs     int64      `json:"duration_ms"`
+	DeliveredAt    *time.Time `gorm:"index" json:"delivered_at"`
+	FailedAt       *time.Time `json:"failed_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
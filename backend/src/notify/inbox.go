@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"gorm.io/gorm"
+)
+
+var inboxMessages = map[string]string{
+	"submit":            "New task '%s' was submitted",
+	"revision":          "Revision requested for task '%s'",
+	"approve":           "Task '%s' was approved by the leader",
+	"manager_approve":   "Task '%s' was approved by the manager",
+	"return":            "Task '%s' was returned to the leader",
+	"reject":            "Task '%s' was rejected",
+	"update_progress":   "Progress updated on task '%s'",
+	"complete":          "Task '%s' was completed",
+	"deadline_reminder": "Deadline for task '%s' is approaching",
+	"overdue":           "Task '%s' is overdue",
+	"escalate":          "Task '%s' was escalated",
+	"reassign":          "Task '%s' was reassigned",
+	"comment_created":   "You were mentioned in a comment on task '%s'",
+	"comment_updated":   "You were mentioned in an edited comment on task '%s'",
+	"task_deleted":      "Task '%s' was deleted",
+}
+
+type InboxNotifier struct {
+	DB *gorm.DB
+}
+
+func (n InboxNotifier) Notify(ctx context.Context, event Event) error {
+	db := n.DB.WithContext(ctx)
+
+	ids := event.Audience()
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var active []uint
+	if err := db.Model(&model.User{}).Where("id IN ? AND active = ?", ids, true).Pluck("id", &active).Error; err != nil {
+		return err
+	}
+	if len(active) == 0 {
+		return nil
+	}
+
+	format, ok := inboxMessages[event.Type]
+	if !ok {
+		format = "Task '%s' was updated (" + event.Type + ")"
+	}
+
+	taskID := event.Task.ID
+	notifications := make([]model.Notification, 0, len(active))
+	for _, userID := range active {
+		notifications = append(notifications, model.Notification{
+			UserID:    userID,
+			TaskID:    &taskID,
+			ActorID:   event.ActorID,
+			Type:      event.Type,
+			Message:   truncate(fmt.Sprintf(format, event.Task.Title), 255),
+			Note:      event.Note,
+			CreatedAt: event.OccurredAt,
+		})
+	}
+
+	return db.Create(&notifications).Error
+}
+
+func truncate(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit-3]) + "..."
+}
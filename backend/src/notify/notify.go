@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/logging"
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+type Event struct {
+	Type       string
+	Task       model.Task
+	ActorID    *uint
+	Note       string
+	Recipients []uint
+	OccurredAt time.Time
+}
+
+func (e Event) Audience() []uint {
+	var ids []uint
+	seen := map[uint]bool{}
+	for _, id := range e.Recipients {
+		if id == 0 || seen[id] || (e.ActorID != nil && *e.ActorID == id) {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+var (
+	mu        sync.RWMutex
+	notifiers []Notifier
+)
+
+func Register(n Notifier) {
+	mu.Lock()
+	defer mu.Unlock()
+	notifiers = append(notifiers, n)
+}
+
+func Publish(ctx context.Context, event Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	mu.RLock()
+	registered := append([]Notifier(nil), notifiers...)
+	mu.RUnlock()
+
+	for _, n := range registered {
+		if err := n.Notify(ctx, event); err != nil {
+			logging.FromContext(ctx).Error("notifier failed", "event", event.Type, "task_id", event.Task.ID, "error", err)
+		}
+	}
+}
+
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, event Event) error {
+	logging.FromContext(ctx).Info("notify", "event", event.Type, "task_id", event.Task.ID, "title", event.Task.Title, "recipients", event.Recipients)
+	return nil
+}
@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"errors"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+var (
+	ErrTaskNotFound = errors.New("task not found")
+	ErrForbidden    = errors.New("not allowed to perform this action on the task")
+)
+
+type Action string
+
+const (
+	ActionView       Action = "view"
+	ActionUpdate     Action = "update"
+	ActionDelete     Action = "delete"
+	ActionProgress   Action = "progress"
+	ActionTransition Action = "transition"
+	ActionReassign   Action = "reassign"
+)
+
+type Actor struct {
+	ID   uint
+	Role string
+}
+
+func CanTask(actor Actor, action Action, task *model.Task) error {
+	if task == nil || task.ID == 0 {
+		return ErrTaskNotFound
+	}
+
+	switch actor.Role {
+	case "pelaksana":
+		if task.CreatedBy != actor.ID || action == ActionReassign {
+			return ErrForbidden
+		}
+		return nil
+	case "leader":
+		if task.AssignedLeader != actor.ID {
+			return ErrForbidden
+		}
+		switch action {
+		case ActionView, ActionProgress, ActionTransition:
+			return nil
+		}
+	case "manager":
+		switch action {
+		case ActionView, ActionTransition, ActionReassign:
+			return nil
+		}
+	}
+
+	return ErrForbidden
+}
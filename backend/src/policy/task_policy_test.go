@@ -0,0 +1,81 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+func TestCanTask(t *testing.T) {
+	const (
+		creatorID = 1
+		otherID   = 2
+		leaderID  = 3
+		managerID = 4
+	)
+	task := &model.Task{ID: 10, CreatedBy: creatorID, AssignedLeader: leaderID}
+
+	allActions := []Action{ActionView, ActionUpdate, ActionDelete, ActionProgress, ActionTransition, ActionReassign}
+
+	tests := []struct {
+		name    string
+		actor   Actor
+		allowed []Action
+	}{
+		{
+			name:    "pelaksana creator",
+			actor:   Actor{ID: creatorID, Role: "pelaksana"},
+			allowed: []Action{ActionView, ActionUpdate, ActionDelete, ActionProgress, ActionTransition},
+		},
+		{
+			name:  "pelaksana non-creator",
+			actor: Actor{ID: otherID, Role: "pelaksana"},
+		},
+		{
+			name:    "leader assigned",
+			actor:   Actor{ID: leaderID, Role: "leader"},
+			allowed: []Action{ActionView, ActionProgress, ActionTransition},
+		},
+		{
+			name:  "leader not assigned",
+			actor: Actor{ID: otherID, Role: "leader"},
+		},
+		{
+			name:    "manager",
+			actor:   Actor{ID: managerID, Role: "manager"},
+			allowed: []Action{ActionView, ActionTransition, ActionReassign},
+		},
+		{
+			name:  "unknown role",
+			actor: Actor{ID: creatorID, Role: "guest"},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, action := range allActions {
+			want := ErrForbidden
+			for _, allowed := range tt.allowed {
+				if allowed == action {
+					want = nil
+				}
+			}
+
+			t.Run(tt.name+"/"+string(action), func(t *testing.T) {
+				if err := CanTask(tt.actor, action, task); !errors.Is(err, want) {
+					t.Fatalf("CanTask() = %v, want %v", err, want)
+				}
+			})
+		}
+	}
+}
+
+func TestCanTaskMissingTask(t *testing.T) {
+	actor := Actor{ID: 1, Role: "manager"}
+
+	for _, task := range []*model.Task{nil, {}} {
+		if err := CanTask(actor, ActionView, task); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("CanTask(%v) = %v, want %v", task, err, ErrTaskNotFound)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormTaskRepository struct {
+	DB *gorm.DB
+}
+
+func NewGormTaskRepository(db *gorm.DB) *GormTaskRepository {
+	return &GormTaskRepository{DB: db}
+}
+
+func (r *GormTaskRepository) WithinTx(ctx context.Context, fn func(TaskRepository) error) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&GormTaskRepository{DB: tx})
+	})
+}
+
+func (r *GormTaskRepository) Find(ctx context.Context, id uint) (model.Task, error) {
+	return r.first(r.DB.WithContext(ctx), id)
+}
+
+func (r *GormTaskRepository) FindForUpdate(ctx context.Context, id uint) (model.Task, error) {
+	db := r.DB.WithContext(ctx)
+	if db.Dialector.Name() != "sqlite" {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	return r.first(db, id)
+}
+
+func (r *GormTaskRepository) FindWithHistories(ctx context.Context, id uint) (model.Task, error) {
+	return r.first(r.DB.WithContext(ctx).Preload("LeaderUser").Preload("TaskHistories"), id)
+}
+
+func (r *GormTaskRepository) FindDetail(ctx context.Context, id uint) (model.Task, error) {
+	return r.first(r.DB.WithContext(ctx).Preload("CreatedByUser").Preload("LeaderUser").Preload("ProgressUser"), id)
+}
+
+func (r *GormTaskRepository) List(ctx context.Context, query TaskQuery) ([]model.Task, int64, error) {
+	filtered := filterTasks(scopeTasks(r.DB.WithContext(ctx).Model(&model.Task{}), query.Scope), query)
+
+	var total int64
+	if err := filtered.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	find := filtered.
+		Preload("CreatedByUser").
+		Preload("LeaderUser").
+		Preload("ProgressUser")
+	if query.WithHistories {
+		find = find.Preload("TaskHistories.ActionUser").Preload("TaskHistories.Changes")
+	}
+	for _, order := range query.Sort {
+		find = find.Order(clause.OrderByColumn{Column: clause.Column{Name: order.Column}, Desc: order.Desc})
+	}
+
+	var tasks []model.Task
+	if err := find.Offset(query.Offset).Limit(query.Limit).Find(&tasks).Error; err != nil {
+		return nil, 0, err
+	}
+	return tasks, total, nil
+}
+
+func scopeTasks(db *gorm.DB, scope TaskScope) *gorm.DB {
+	if scope.CreatedBy != 0 {
+		db = db.Where("created_by = ?", scope.CreatedBy)
+	}
+	if scope.AssignedLeader != 0 {
+		db = db.Where("assigned_leader = ?", scope.AssignedLeader)
+	}
+
+	switch {
+	case len(scope.Statuses) > 0 && scope.OrEscalated:
+		db = db.Where("status IN ? OR escalated_at IS NOT NULL", scope.Statuses)
+	case len(scope.Statuses) > 0:
+		db = db.Where("status IN ?", scope.Statuses)
+	case scope.OrEscalated:
+		db = db.Where("escalated_at IS NOT NULL")
+	}
+	return db
+}
+
+func filterTasks(db *gorm.DB, query TaskQuery) *gorm.DB {
+	if len(query.Statuses) > 0 {
+		db = db.Where("status IN ?", query.Statuses)
+	}
+	if query.LeaderID != 0 {
+		db = db.Where("assigned_leader = ?", query.LeaderID)
+	}
+	if query.CreatedBy != 0 {
+		db = db.Where("created_by = ?", query.CreatedBy)
+	}
+
+	if query.DeadlineFrom != nil {
+		db = db.Where("deadline >= ?", *query.DeadlineFrom)
+	}
+	if query.DeadlineBefore != nil {
+		db = db.Where("deadline < ?", *query.DeadlineBefore)
+	}
+	if query.DeadlineUntil != nil {
+		db = db.Where("deadline <= ?", *query.DeadlineUntil)
+	}
+
+	if query.ProgressMin != nil {
+		db = db.Where("progress >= ?", *query.ProgressMin)
+	}
+	if query.ProgressMax != nil {
+		db = db.Where("progress <= ?", *query.ProgressMax)
+	}
+
+	if query.Overdue != nil {
+		if *query.Overdue {
+			db = db.Where("overdue_at IS NOT NULL")
+		} else {
+			db = db.Where("overdue_at IS NULL")
+		}
+	}
+
+	if query.Escalated != nil {
+		if *query.Escalated {
+			db = db.Where("escalated_at IS NOT NULL")
+		} else {
+			db = db.Where("escalated_at IS NULL")
+		}
+	}
+
+	if query.Search != "" {
+		pattern := "%" + strings.ToLower(query.Search) + "%"
+		db = db.Where("LOWER(title) LIKE ? OR LOWER(description) LIKE ?", pattern, pattern)
+	}
+	return db
+}
+
+func (r *GormTaskRepository) first(db *gorm.DB, id uint) (model.Task, error) {
+	var task model.Task
+	if err := db.First(&task, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Task{}, ErrNotFound
+		}
+		return model.Task{}, err
+	}
+	return task, nil
+}
+
+func (r *GormTaskRepository) Create(ctx context.Context, task *model.Task, history *model.TaskHistory) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(task).Error; err != nil {
+			return err
+		}
+		history.TaskID = task.ID
+		return tx.Create(history).Error
+	})
+}
+
+func (r *GormTaskRepository) Update(ctx context.Context, task model.Task, changes map[string]interface{}, history *model.TaskHistory) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Task{}).
+			Where("id = ? AND version = ?", task.ID, task.Version).
+			Updates(changes)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrConflict
+		}
+
+		history.TaskID = task.ID
+		return tx.Create(history).Error
+	})
+}
+
+func (r *GormTaskRepository) Delete(ctx context.Context, task model.Task, history *model.TaskHistory) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Task{}).
+			Where("id = ? AND version = ?", task.ID, task.Version).
+			Updates(map[string]interface{}{
+				"deleted_at": time.Now(),
+				"version":    gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrConflict
+		}
+
+		if err := tx.Where("task_id = ?", task.ID).Delete(&model.TaskAttachment{}).Error; err != nil {
+			return err
+		}
+
+		history.TaskID = task.ID
+		return tx.Create(history).Error
+	})
+}
+
+type GormUserRepository struct {
+	DB *gorm.DB
+}
+
+func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
+	return &GormUserRepository{DB: db}
+}
+
+func (r *GormUserRepository) Find(ctx context.Context, id uint) (model.User, error) {
+	var user model.User
+	if err := r.DB.WithContext(ctx).First(&user, id).Error; err != nil {
+		return model.User{}, notFound(err)
+	}
+	return user, nil
+}
+
+func (r *GormUserRepository) FindByUsername(ctx context.Context, username string) (model.User, error) {
+	var user model.User
+	if err := r.DB.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
+		return model.User{}, notFound(err)
+	}
+	return user, nil
+}
+
+func (r *GormUserRepository) FindActive(ctx context.Context, id uint, role string) (model.User, error) {
+	var user model.User
+	if err := r.DB.WithContext(ctx).Where("id = ? AND role = ? AND active = ?", id, role, true).First(&user).Error; err != nil {
+		return model.User{}, notFound(err)
+	}
+	return user, nil
+}
+
+func (r *GormUserRepository) ActiveByRole(ctx context.Context, role string) ([]model.User, error) {
+	var users []model.User
+	err := r.DB.WithContext(ctx).Where("role = ? AND active = ?", role, true).Find(&users).Error
+	return users, err
+}
+
+func (r *GormUserRepository) ActiveIDsByRole(ctx context.Context, role string) ([]uint, error) {
+	var ids []uint
+	err := r.DB.WithContext(ctx).Model(&model.User{}).
+		Where("role = ? AND active = ?", role, true).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *GormUserRepository) ActiveByUsernames(ctx context.Context, usernames []string) ([]model.User, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	var users []model.User
+	err := r.DB.WithContext(ctx).Where("username IN ? AND active = ?", usernames, true).Order("id").Find(&users).Error
+	return users, err
+}
+
+func (r *GormUserRepository) List(ctx context.Context, filter UserFilter) ([]model.User, error) {
+	query := r.DB.WithContext(ctx).Model(&model.User{})
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.Active != nil {
+		query = query.Where("active = ?", *filter.Active)
+	}
+
+	var users []model.User
+	err := query.Order("id").Find(&users).Error
+	return users, err
+}
+
+func (r *GormUserRepository) UsernameTaken(ctx context.Context, username string, exceptID uint) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&model.User{}).
+		Where("username = ? AND id <> ?", username, exceptID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *GormUserRepository) Create(ctx context.Context, user *model.User, audit *model.UserAudit) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		if audit == nil {
+			return nil
+		}
+		audit.UserID = user.ID
+		return tx.Create(audit).Error
+	})
+}
+
+func (r *GormUserRepository) Update(ctx context.Context, user model.User, change UserChange) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(change.Updates) > 0 {
+			if err := tx.Model(&model.User{ID: user.ID}).Updates(change.Updates).Error; err != nil {
+				return err
+			}
+		}
+		if change.RevokeSessions {
+			if err := NewGormSessionRepository(tx).RevokeUser(ctx, user.ID, time.Now()); err != nil {
+				return err
+			}
+		}
+		if change.Audit == nil {
+			return nil
+		}
+		change.Audit.UserID = user.ID
+		return tx.Create(change.Audit).Error
+	})
+}
+
+func (r *GormUserRepository) Audits(ctx context.Context, userID uint) ([]model.UserAudit, error) {
+	var audits []model.UserAudit
+	err := r.DB.WithContext(ctx).
+		Preload("ActionUser").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&audits).Error
+	return audits, err
+}
+
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func NewGormRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Tasks:          NewGormTaskRepository(db),
+		Users:          NewGormUserRepository(db),
+		Histories:      NewGormHistoryRepository(db),
+		Comments:       NewGormCommentRepository(db),
+		Attachments:    NewGormAttachmentRepository(db),
+		Notifications:  NewGormNotificationRepository(db),
+		Webhooks:       NewGormWebhookRepository(db),
+		Sessions:       NewGormSessionRepository(db),
+		PasswordResets: NewGormPasswordResetRepository(db),
+	}
+}
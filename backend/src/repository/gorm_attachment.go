@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"gorm.io/gorm"
+)
+
+type GormAttachmentRepository struct {
+	DB *gorm.DB
+}
+
+func NewGormAttachmentRepository(db *gorm.DB) *GormAttachmentRepository {
+	return &GormAttachmentRepository{DB: db}
+}
+
+func (r *GormAttachmentRepository) Find(ctx context.Context, taskID uint, id uint) (model.TaskAttachment, error) {
+	var attachment model.TaskAttachment
+	if err := r.DB.WithContext(ctx).
+		Preload("Uploader").
+		Where("id = ? AND task_id = ?", id, taskID).
+		First(&attachment).Error; err != nil {
+		return model.TaskAttachment{}, notFound(err)
+	}
+	return attachment, nil
+}
+
+func (r *GormAttachmentRepository) ListByTask(ctx context.Context, taskID uint) ([]model.TaskAttachment, error) {
+	var attachments []model.TaskAttachment
+	err := r.DB.WithContext(ctx).
+		Preload("Uploader").
+		Where("task_id = ?", taskID).
+		Order("created_at ASC, id ASC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *GormAttachmentRepository) StorageKeys(ctx context.Context, taskID uint) ([]string, error) {
+	var keys []string
+	err := r.DB.WithContext(ctx).Model(&model.TaskAttachment{}).Where("task_id = ?", taskID).Pluck("storage_key", &keys).Error
+	return keys, err
+}
+
+func (r *GormAttachmentRepository) Create(ctx context.Context, attachment *model.TaskAttachment) error {
+	return r.DB.WithContext(ctx).Create(attachment).Error
+}
+
+func (r *GormAttachmentRepository) Delete(ctx context.Context, attachment model.TaskAttachment) error {
+	return r.DB.WithContext(ctx).Delete(&attachment).Error
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"gorm.io/gorm"
+)
+
+type GormCommentRepository struct {
+	DB *gorm.DB
+}
+
+func NewGormCommentRepository(db *gorm.DB) *GormCommentRepository {
+	return &GormCommentRepository{DB: db}
+}
+
+func (r *GormCommentRepository) Find(ctx context.Context, taskID uint, id uint) (model.TaskComment, error) {
+	var comment model.TaskComment
+	if err := r.DB.WithContext(ctx).
+		Preload("Author").
+		Preload("Mentions").
+		Where("id = ? AND task_id = ?", id, taskID).
+		First(&comment).Error; err != nil {
+		return model.TaskComment{}, notFound(err)
+	}
+	return comment, nil
+}
+
+func (r *GormCommentRepository) Threads(ctx context.Context, taskID uint, offset int, limit int) ([]model.TaskComment, int64, error) {
+	threads := r.DB.WithContext(ctx).Model(&model.TaskComment{}).Where("task_id = ? AND parent_id IS NULL", taskID)
+
+	var total int64
+	if err := threads.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var comments []model.TaskComment
+	err := threads.
+		Preload("Author").
+		Preload("Mentions").
+		Preload("Replies", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC, id ASC")
+		}).
+		Preload("Replies.Author").
+		Preload("Replies.Mentions").
+		Order("created_at ASC, id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&comments).Error
+	return comments, total, err
+}
+
+func (r *GormCommentRepository) Create(ctx context.Context, comment *model.TaskComment) error {
+	return r.DB.WithContext(ctx).Create(comment).Error
+}
+
+func (r *GormCommentRepository) Update(ctx context.Context, comment model.TaskComment, body string, editedAt time.Time, mentions []model.User) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&comment).Updates(model.TaskComment{Body: body, EditedAt: &editedAt}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&comment).Association("Mentions").Replace(mentions)
+	})
+}
+
+func (r *GormCommentRepository) Delete(ctx context.Context, comment model.TaskComment, deletedAt time.Time) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&comment).Association("Mentions").Clear(); err != nil {
+			return err
+		}
+		return tx.Model(&comment).Updates(map[string]interface{}{
+			"body":       "",
+			"deleted_at": deletedAt,
+		}).Error
+	})
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"gorm.io/gorm"
+)
+
+type GormHistoryRepository struct {
+	DB *gorm.DB
+}
+
+func NewGormHistoryRepository(db *gorm.DB) *GormHistoryRepository {
+	return &GormHistoryRepository{DB: db}
+}
+
+func (r *GormHistoryRepository) List(ctx context.Context, query HistoryQuery) ([]model.TaskHistory, int64, error) {
+	db := r.DB.WithContext(ctx)
+	histories := db.Model(&model.TaskHistory{}).Where("task_id = ?", query.TaskID)
+	if query.Field != "" {
+		histories = histories.Where("id IN (?)", db.
+			Model(&model.TaskHistoryChange{}).
+			Select("history_id").
+			Where("field = ?", query.Field))
+	}
+
+	var total int64
+	if err := histories.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "created_at ASC, id ASC"
+	if query.Desc {
+		order = "created_at DESC, id DESC"
+	}
+
+	var items []model.TaskHistory
+	err := histories.
+		Preload("ActionUser").
+		Preload("Changes").
+		Order(order).
+		Offset(query.Offset).
+		Limit(query.Limit).
+		Find(&items).Error
+	return items, total, err
+}
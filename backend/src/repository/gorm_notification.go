@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"gorm.io/gorm"
+)
+
+type GormNotificationRepository struct {
+	DB *gorm.DB
+}
+
+func NewGormNotificationRepository(db *gorm.DB) *GormNotificationRepository {
+	return &GormNotificationRepository{DB: db}
+}
+
+func (r *GormNotificationRepository) List(ctx context.Context, query NotificationQuery) ([]model.Notification, int64, error) {
+	notifications := r.DB.WithContext(ctx).Model(&model.Notification{}).Where("user_id = ?", query.UserID)
+	if query.Unread != nil {
+		if *query.Unread {
+			notifications = notifications.Where("read_at IS NULL")
+		} else {
+			notifications = notifications.Where("read_at IS NOT NULL")
+		}
+	}
+
+	var total int64
+	if err := notifications.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.Notification
+	err := notifications.
+		Preload("Actor").
+		Order("created_at DESC, id DESC").
+		Offset(query.Offset).
+		Limit(query.Limit).
+		Find(&items).Error
+	return items, total, err
+}
+
+func (r *GormNotificationRepository) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *GormNotificationRepository) Find(ctx context.Context, userID uint, id uint) (model.Notification, error) {
+	var notification model.Notification
+	if err := r.DB.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		return model.Notification{}, notFound(err)
+	}
+	return notification, nil
+}
+
+func (r *GormNotificationRepository) MarkRead(ctx context.Context, id uint, readAt time.Time) error {
+	return r.DB.WithContext(ctx).Model(&model.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Update("read_at", readAt).Error
+}
+
+func (r *GormNotificationRepository) MarkAllRead(ctx context.Context, userID uint, readAt time.Time) (int64, error) {
+	result := r.DB.WithContext(ctx).Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"gorm.io/gorm"
+)
+
+type GormSessionRepository struct {
+	DB *gorm.DB
+}
+
+func NewGormSessionRepository(db *gorm.DB) *GormSessionRepository {
+	return &GormSessionRepository{DB: db}
+}
+
+func (r *GormSessionRepository) Create(ctx context.Context, session *model.Session, token *model.RefreshToken) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		token.SessionID = session.ID
+		return tx.Create(token).Error
+	})
+}
+
+func (r *GormSessionRepository) Find(ctx context.Context, id uint) (model.Session, error) {
+	var session model.Session
+	if err := r.DB.WithContext(ctx).Preload("User").First(&session, id).Error; err != nil {
+		return model.Session{}, notFound(err)
+	}
+	return session, nil
+}
+
+func (r *GormSessionRepository) FindRefreshToken(ctx context.Context, tokenHash string) (model.RefreshToken, error) {
+	var token model.RefreshToken
+	if err := r.DB.WithContext(ctx).
+		Preload("Session.User").
+		Where("token_hash = ?", tokenHash).
+		First(&token).Error; err != nil {
+		return model.RefreshToken{}, notFound(err)
+	}
+	return token, nil
+}
+
+func (r *GormSessionRepository) Rotate(ctx context.Context, used model.RefreshToken, usedAt time.Time, next *model.RefreshToken) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", used.ID).
+			Update("used_at", usedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrConflict
+		}
+
+		if err := tx.Model(&model.Session{ID: used.SessionID}).Update("expires_at", next.ExpiresAt).Error; err != nil {
+			return err
+		}
+
+		next.SessionID = used.SessionID
+		return tx.Create(next).Error
+	})
+}
+
+func (r *GormSessionRepository) Revoke(ctx context.Context, id uint, revokedAt time.Time) error {
+	return r.DB.WithContext(ctx).Model(&model.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (r *GormSessionRepository) RevokeUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	return r.DB.WithContext(ctx).Model(&model.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
+
+type GormPasswordResetRepository struct {
+	DB *gorm.DB
+}
+
+func NewGormPasswordResetRepository(db *gorm.DB) *GormPasswordResetRepository {
+	return &GormPasswordResetRepository{DB: db}
+}
+
+func (r *GormPasswordResetRepository) Issue(ctx context.Context, token *model.PasswordResetToken, audit *model.UserAudit) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(token).Error; err != nil {
+			return err
+		}
+		if audit == nil {
+			return nil
+		}
+		audit.UserID = token.UserID
+		return tx.Create(audit).Error
+	})
+}
+
+func (r *GormPasswordResetRepository) FindByHash(ctx context.Context, tokenHash string) (model.PasswordResetToken, error) {
+	var token model.PasswordResetToken
+	if err := r.DB.WithContext(ctx).
+		Preload("User").
+		Where("token_hash = ?", tokenHash).
+		First(&token).Error; err != nil {
+		return model.PasswordResetToken{}, notFound(err)
+	}
+	return token, nil
+}
+
+func (r *GormPasswordResetRepository) Redeem(ctx context.Context, token model.PasswordResetToken, passwordHash string, usedAt time.Time) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", usedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrConflict
+		}
+
+		if err := tx.Model(&model.User{ID: token.UserID}).Update("password", passwordHash).Error; err != nil {
+			return err
+		}
+		return NewGormSessionRepository(tx).RevokeUser(ctx, token.UserID, usedAt)
+	})
+}
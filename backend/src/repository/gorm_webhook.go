@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ardhia137/task_todo/src/model"
+	"gorm.io/gorm"
+)
+
+type GormWebhookRepository struct {
+	DB *gorm.DB
+}
+
+func NewGormWebhookRepository(db *gorm.DB) *GormWebhookRepository {
+	return &GormWebhookRepository{DB: db}
+}
+
+func (r *GormWebhookRepository) List(ctx context.Context) ([]model.Webhook, error) {
+	var hooks []model.Webhook
+	err := r.DB.WithContext(ctx).Preload("Creator").Order("id ASC").Find(&hooks).Error
+	return hooks, err
+}
+
+func (r *GormWebhookRepository) Find(ctx context.Context, id uint) (model.Webhook, error) {
+	var hook model.Webhook
+	if err := r.DB.WithContext(ctx).Preload("Creator").First(&hook, id).Error; err != nil {
+		return model.Webhook{}, notFound(err)
+	}
+	return hook, nil
+}
+
+func (r *GormWebhookRepository) Create(ctx context.Context, hook *model.Webhook) error {
+	return r.DB.WithContext(ctx).Create(hook).Error
+}
+
+func (r *GormWebhookRepository) Update(ctx context.Context, hook model.Webhook) error {
+	return r.DB.WithContext(ctx).Select("url", "events", "description", "active").Updates(&hook).Error
+}
+
+func (r *GormWebhookRepository) RotateSecret(ctx context.Context, id uint, secret string) error {
+	return r.DB.WithContext(ctx).Model(&model.Webhook{ID: id}).Update("secret", secret).Error
+}
+
+func (r *GormWebhookRepository) Delete(ctx context.Context, hook model.Webhook) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", hook.ID).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&hook).Error
+	})
+}
+
+func (r *GormWebhookRepository) Deliveries(ctx context.Context, query DeliveryQuery) ([]model.WebhookDelivery, int64, error) {
+	deliveries := r.DB.WithContext(ctx).Model(&model.WebhookDelivery{}).Where("webhook_id = ?", query.WebhookID)
+	switch query.Status {
+	case "pending":
+		deliveries = deliveries.Where("delivered_at IS NULL AND failed_at IS NULL")
+	case "delivered":
+		deliveries = deliveries.Where("delivered_at IS NOT NULL")
+	case "failed":
+		deliveries = deliveries.Where("failed_at IS NOT NULL")
+	}
+
+	var total int64
+	if err := deliveries.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.WebhookDelivery
+	err := deliveries.
+		Order("id DESC").
+		Offset(query.Offset).
+		Limit(query.Limit).
+		Find(&items).Error
+	return items, total, err
+}
+
+func (r *GormWebhookRepository) FindDelivery(ctx context.Context, webhookID uint, id uint) (model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	if err := r.DB.WithContext(ctx).
+		Where("id = ? AND webhook_id = ?", id, webhookID).
+		First(&delivery).Error; err != nil {
+		return model.WebhookDelivery{}, notFound(err)
+	}
+	return delivery, nil
+}
+
+func (r *GormWebhookRepository) CreateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	return r.DB.WithContext(ctx).Create(delivery).Error
+}
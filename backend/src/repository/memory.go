@@ -0,0 +1,568 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+type MemoryTaskRepository struct {
+	mu        sync.Mutex
+	txMu      sync.Mutex
+	tasks     map[uint]model.Task
+	histories map[uint][]model.TaskHistory
+	lastID    uint
+	historyID uint
+	changeID  uint
+}
+
+func NewMemoryTaskRepository(tasks ...model.Task) *MemoryTaskRepository {
+	r := &MemoryTaskRepository{
+		tasks:     map[uint]model.Task{},
+		histories: map[uint][]model.TaskHistory{},
+	}
+	for _, task := range tasks {
+		if task.ID > r.lastID {
+			r.lastID = task.ID
+		}
+		r.tasks[task.ID] = task
+	}
+	return r
+}
+
+func (r *MemoryTaskRepository) WithinTx(ctx context.Context, fn func(TaskRepository) error) error {
+	r.txMu.Lock()
+	defer r.txMu.Unlock()
+
+	r.mu.Lock()
+	tasks := make(map[uint]model.Task, len(r.tasks))
+	for id, task := range r.tasks {
+		tasks[id] = task
+	}
+	histories := make(map[uint][]model.TaskHistory, len(r.histories))
+	for id, entries := range r.histories {
+		histories[id] = append([]model.TaskHistory(nil), entries...)
+	}
+	r.mu.Unlock()
+
+	if err := fn(r); err != nil {
+		r.mu.Lock()
+		r.tasks, r.histories = tasks, histories
+		r.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (r *MemoryTaskRepository) Find(ctx context.Context, id uint) (model.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.tasks[id]
+	if !ok {
+		return model.Task{}, ErrNotFound
+	}
+	return task, nil
+}
+
+func (r *MemoryTaskRepository) FindForUpdate(ctx context.Context, id uint) (model.Task, error) {
+	return r.Find(ctx, id)
+}
+
+func (r *MemoryTaskRepository) FindWithHistories(ctx context.Context, id uint) (model.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.tasks[id]
+	if !ok {
+		return model.Task{}, ErrNotFound
+	}
+	task.TaskHistories = append([]model.TaskHistory(nil), r.histories[id]...)
+	return task, nil
+}
+
+func (r *MemoryTaskRepository) FindDetail(ctx context.Context, id uint) (model.Task, error) {
+	return r.Find(ctx, id)
+}
+
+func (r *MemoryTaskRepository) List(ctx context.Context, query TaskQuery) ([]model.Task, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var tasks []model.Task
+	for _, task := range r.tasks {
+		if matchesScope(task, query.Scope) && matchesQuery(task, query) {
+			if query.WithHistories {
+				task.TaskHistories = append([]model.TaskHistory(nil), r.histories[task.ID]...)
+			}
+			tasks = append(tasks, task)
+		}
+	}
+
+	for _, order := range query.Sort {
+		if _, err := compareTasks(model.Task{}, model.Task{}, order.Column); err != nil {
+			return nil, 0, err
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		for _, order := range query.Sort {
+			if c, _ := compareTasks(tasks[i], tasks[j], order.Column); c != 0 {
+				return (c < 0) != order.Desc
+			}
+		}
+		return false
+	})
+
+	return pageOf(tasks, query.Offset, query.Limit), int64(len(tasks)), nil
+}
+
+func pageOf[T any](items []T, offset int, limit int) []T {
+	start := min(offset, len(items))
+	end := len(items)
+	if limit > 0 {
+		end = min(start+limit, len(items))
+	}
+	return items[start:end]
+}
+
+func matchesScope(task model.Task, scope TaskScope) bool {
+	if scope.CreatedBy != 0 && task.CreatedBy != scope.CreatedBy {
+		return false
+	}
+	if scope.AssignedLeader != 0 && task.AssignedLeader != scope.AssignedLeader {
+		return false
+	}
+	if len(scope.Statuses) > 0 || scope.OrEscalated {
+		return slices.Contains(scope.Statuses, task.Status) || (scope.OrEscalated && task.EscalatedAt != nil)
+	}
+	return true
+}
+
+func matchesQuery(task model.Task, query TaskQuery) bool {
+	switch {
+	case len(query.Statuses) > 0 && !slices.Contains(query.Statuses, task.Status),
+		query.LeaderID != 0 && task.AssignedLeader != query.LeaderID,
+		query.CreatedBy != 0 && task.CreatedBy != query.CreatedBy,
+		query.DeadlineFrom != nil && task.Deadline.Before(*query.DeadlineFrom),
+		query.DeadlineBefore != nil && !task.Deadline.Before(*query.DeadlineBefore),
+		query.DeadlineUntil != nil && task.Deadline.After(*query.DeadlineUntil),
+		query.ProgressMin != nil && task.Progress < *query.ProgressMin,
+		query.ProgressMax != nil && task.Progress > *query.ProgressMax,
+		query.Overdue != nil && *query.Overdue != (task.OverdueAt != nil),
+		query.Escalated != nil && *query.Escalated != (task.EscalatedAt != nil):
+		return false
+	}
+
+	if query.Search != "" {
+		search := strings.ToLower(query.Search)
+		return strings.Contains(strings.ToLower(task.Title), search) ||
+			strings.Contains(strings.ToLower(task.Description), search)
+	}
+	return true
+}
+
+func compareTasks(a, b model.Task, column string) (int, error) {
+	switch column {
+	case "id":
+		return cmp.Compare(a.ID, b.ID), nil
+	case "title":
+		return strings.Compare(a.Title, b.Title), nil
+	case "status":
+		return strings.Compare(a.Status, b.Status), nil
+	case "progress":
+		return cmp.Compare(a.Progress, b.Progress), nil
+	case "deadline":
+		return a.Deadline.Compare(b.Deadline), nil
+	}
+	return 0, fmt.Errorf("memory repository cannot sort by %s", column)
+}
+
+func (r *MemoryTaskRepository) Histories(id uint) []model.TaskHistory {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]model.TaskHistory(nil), r.histories[id]...)
+}
+
+func (r *MemoryTaskRepository) Create(ctx context.Context, task *model.Task, history *model.TaskHistory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	task.ID = r.lastID
+	if task.Version == 0 {
+		task.Version = 1
+	}
+	r.tasks[task.ID] = *task
+
+	history.TaskID = task.ID
+	r.appendHistory(history)
+	return nil
+}
+
+func (r *MemoryTaskRepository) Update(ctx context.Context, task model.Task, changes map[string]interface{}, history *model.TaskHistory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tasks[task.ID]
+	if !ok || stored.Version != task.Version {
+		return ErrConflict
+	}
+
+	columns := make([]string, 0, len(changes))
+	for column := range changes {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		if err := applyTaskChange(&stored, column, changes[column]); err != nil {
+			return err
+		}
+	}
+	r.tasks[task.ID] = stored
+
+	history.TaskID = task.ID
+	r.appendHistory(history)
+	return nil
+}
+
+func (r *MemoryTaskRepository) Delete(ctx context.Context, task model.Task, history *model.TaskHistory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tasks[task.ID]
+	if !ok || stored.Version != task.Version {
+		return ErrConflict
+	}
+	delete(r.tasks, task.ID)
+
+	history.TaskID = task.ID
+	r.appendHistory(history)
+	return nil
+}
+
+func (r *MemoryTaskRepository) appendHistory(history *model.TaskHistory) {
+	r.historyID++
+	history.ID = r.historyID
+	if history.CreatedAt.IsZero() {
+		history.CreatedAt = time.Now()
+	}
+	for i := range history.Changes {
+		r.changeID++
+		history.Changes[i].ID = r.changeID
+		history.Changes[i].HistoryID = history.ID
+	}
+	r.histories[history.TaskID] = append(r.histories[history.TaskID], *history)
+}
+
+func applyTaskChange(task *model.Task, column string, value interface{}) error {
+	var ok bool
+	switch column {
+	case "title":
+		task.Title, ok = value.(string)
+	case "description":
+		task.Description, ok = value.(string)
+	case "status":
+		task.Status, ok = value.(string)
+	case "escalation_rule":
+		task.EscalationRule, ok = value.(string)
+	case "assigned_leader":
+		task.AssignedLeader, ok = value.(uint)
+	case "progress_by":
+		task.ProgressBy, ok = value.(uint)
+	case "version":
+		task.Version, ok = value.(uint)
+	case "progress":
+		task.Progress, ok = value.(int)
+	case "deadline":
+		task.Deadline, ok = value.(time.Time)
+	case "reminder_sent_at":
+		task.ReminderSentAt, ok = optionalTime(value)
+	case "overdue_at":
+		task.OverdueAt, ok = optionalTime(value)
+	case "escalated_at":
+		task.EscalatedAt, ok = optionalTime(value)
+	}
+	if !ok {
+		return fmt.Errorf("memory repository cannot apply %s=%v", column, value)
+	}
+	return nil
+}
+
+func optionalTime(value interface{}) (*time.Time, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, true
+	case time.Time:
+		return &v, true
+	case *time.Time:
+		return v, true
+	}
+	return nil, false
+}
+
+type MemoryUserRepository struct {
+	mu       sync.Mutex
+	users    map[uint]model.User
+	audits   []model.UserAudit
+	lastID   uint
+	auditID  uint
+	sessions *MemorySessionRepository
+}
+
+func NewMemoryUserRepository(users ...model.User) *MemoryUserRepository {
+	r := &MemoryUserRepository{users: map[uint]model.User{}}
+	for _, user := range users {
+		if user.ID > r.lastID {
+			r.lastID = user.ID
+		}
+		r.users[user.ID] = user
+	}
+	return r
+}
+
+func (r *MemoryUserRepository) Find(ctx context.Context, id uint) (model.User, error) {
+	user, ok := r.lookup(id)
+	if !ok {
+		return model.User{}, ErrNotFound
+	}
+	return user, nil
+}
+
+func (r *MemoryUserRepository) FindByUsername(ctx context.Context, username string) (model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return model.User{}, ErrNotFound
+}
+
+func (r *MemoryUserRepository) FindActive(ctx context.Context, id uint, role string) (model.User, error) {
+	user, ok := r.lookup(id)
+	if !ok || user.Role != role || !user.Active {
+		return model.User{}, ErrNotFound
+	}
+	return user, nil
+}
+
+func (r *MemoryUserRepository) ActiveByRole(ctx context.Context, role string) ([]model.User, error) {
+	active := true
+	return r.List(ctx, UserFilter{Role: role, Active: &active})
+}
+
+func (r *MemoryUserRepository) ActiveIDsByRole(ctx context.Context, role string) ([]uint, error) {
+	users, err := r.ActiveByRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uint
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids, nil
+}
+
+func (r *MemoryUserRepository) ActiveByUsernames(ctx context.Context, usernames []string) ([]model.User, error) {
+	active := true
+	users, err := r.List(ctx, UserFilter{Active: &active})
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []model.User
+	for _, user := range users {
+		if slices.Contains(usernames, user.Username) {
+			matched = append(matched, user)
+		}
+	}
+	return matched, nil
+}
+
+func (r *MemoryUserRepository) List(ctx context.Context, filter UserFilter) ([]model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var users []model.User
+	for _, user := range r.users {
+		if filter.Role != "" && user.Role != filter.Role {
+			continue
+		}
+		if filter.Active != nil && user.Active != *filter.Active {
+			continue
+		}
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
+
+func (r *MemoryUserRepository) UsernameTaken(ctx context.Context, username string, exceptID uint) (bool, error) {
+	user, err := r.FindByUsername(ctx, username)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return err == nil && user.ID != exceptID, err
+}
+
+func (r *MemoryUserRepository) Create(ctx context.Context, user *model.User, audit *model.UserAudit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if existing.Username == user.Username {
+			return ErrConflict
+		}
+	}
+
+	r.lastID++
+	user.ID = r.lastID
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+		user.UpdatedAt = user.CreatedAt
+	}
+	r.users[user.ID] = *user
+
+	if audit != nil {
+		audit.UserID = user.ID
+		r.appendAudit(audit)
+	}
+	return nil
+}
+
+func (r *MemoryUserRepository) Update(ctx context.Context, user model.User, change UserChange) error {
+	r.mu.Lock()
+	stored, ok := r.users[user.ID]
+	if !ok {
+		r.mu.Unlock()
+		return ErrNotFound
+	}
+
+	columns := make([]string, 0, len(change.Updates))
+	for column := range change.Updates {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		if err := applyUserChange(&stored, column, change.Updates[column]); err != nil {
+			r.mu.Unlock()
+			return err
+		}
+	}
+	if len(columns) > 0 {
+		stored.UpdatedAt = time.Now()
+	}
+	r.users[user.ID] = stored
+
+	if change.Audit != nil {
+		change.Audit.UserID = user.ID
+		r.appendAudit(change.Audit)
+	}
+	sessions := r.sessions
+	r.mu.Unlock()
+
+	if change.RevokeSessions && sessions != nil {
+		return sessions.RevokeUser(ctx, user.ID, time.Now())
+	}
+	return nil
+}
+
+func (r *MemoryUserRepository) Audits(ctx context.Context, userID uint) ([]model.UserAudit, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var audits []model.UserAudit
+	for _, audit := range r.audits {
+		if audit.UserID == userID {
+			audit.ActionUser = r.users[audit.ActionBy]
+			audits = append(audits, audit)
+		}
+	}
+	sort.SliceStable(audits, func(i, j int) bool { return audits[i].CreatedAt.After(audits[j].CreatedAt) })
+	return audits, nil
+}
+
+func (r *MemoryUserRepository) addAudit(audit *model.UserAudit) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.appendAudit(audit)
+}
+
+func (r *MemoryUserRepository) appendAudit(audit *model.UserAudit) {
+	r.auditID++
+	audit.ID = r.auditID
+	if audit.CreatedAt.IsZero() {
+		audit.CreatedAt = time.Now()
+	}
+	r.audits = append(r.audits, *audit)
+}
+
+func (r *MemoryUserRepository) lookup(id uint) (model.User, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	return user, ok
+}
+
+func (r *MemoryUserRepository) lookupRef(id *uint) *model.User {
+	if id == nil {
+		return nil
+	}
+	if user, ok := r.lookup(*id); ok {
+		return &user
+	}
+	return nil
+}
+
+func applyUserChange(user *model.User, column string, value interface{}) error {
+	var ok bool
+	switch column {
+	case "username":
+		user.Username, ok = value.(string)
+	case "password":
+		user.Password, ok = value.(string)
+	case "email":
+		user.Email, ok = value.(string)
+	case "role":
+		user.Role, ok = value.(string)
+	case "active":
+		user.Active, ok = value.(bool)
+	}
+	if !ok {
+		return fmt.Errorf("memory repository cannot apply %s=%v", column, value)
+	}
+	return nil
+}
+
+func NewMemoryRepositories(tasks *MemoryTaskRepository, users *MemoryUserRepository) Repositories {
+	sessions := NewMemorySessionRepository(users)
+	users.mu.Lock()
+	users.sessions = sessions
+	users.mu.Unlock()
+
+	return Repositories{
+		Tasks:          tasks,
+		Users:          users,
+		Histories:      NewMemoryHistoryRepository(tasks, users),
+		Comments:       NewMemoryCommentRepository(users),
+		Attachments:    NewMemoryAttachmentRepository(users),
+		Notifications:  NewMemoryNotificationRepository(users),
+		Webhooks:       NewMemoryWebhookRepository(users),
+		Sessions:       sessions,
+		PasswordResets: NewMemoryPasswordResetRepository(users),
+	}
+}
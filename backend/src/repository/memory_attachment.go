@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+type MemoryAttachmentRepository struct {
+	mu          sync.Mutex
+	attachments map[uint]model.TaskAttachment
+	lastID      uint
+	users       *MemoryUserRepository
+}
+
+func NewMemoryAttachmentRepository(users *MemoryUserRepository, attachments ...model.TaskAttachment) *MemoryAttachmentRepository {
+	r := &MemoryAttachmentRepository{attachments: map[uint]model.TaskAttachment{}, users: users}
+	for _, attachment := range attachments {
+		if attachment.ID > r.lastID {
+			r.lastID = attachment.ID
+		}
+		r.attachments[attachment.ID] = attachment
+	}
+	return r
+}
+
+func (r *MemoryAttachmentRepository) Find(ctx context.Context, taskID uint, id uint) (model.TaskAttachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attachment, ok := r.attachments[id]
+	if !ok || attachment.TaskID != taskID {
+		return model.TaskAttachment{}, ErrNotFound
+	}
+	return r.withUploader(attachment), nil
+}
+
+func (r *MemoryAttachmentRepository) ListByTask(ctx context.Context, taskID uint) ([]model.TaskAttachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var attachments []model.TaskAttachment
+	for _, attachment := range r.attachments {
+		if attachment.TaskID == taskID {
+			attachments = append(attachments, r.withUploader(attachment))
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool {
+		if !attachments[i].CreatedAt.Equal(attachments[j].CreatedAt) {
+			return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+		}
+		return attachments[i].ID < attachments[j].ID
+	})
+	return attachments, nil
+}
+
+func (r *MemoryAttachmentRepository) StorageKeys(ctx context.Context, taskID uint) ([]string, error) {
+	attachments, err := r.ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, attachment := range attachments {
+		keys = append(keys, attachment.StorageKey)
+	}
+	return keys, nil
+}
+
+func (r *MemoryAttachmentRepository) Create(ctx context.Context, attachment *model.TaskAttachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.attachments {
+		if existing.StorageKey == attachment.StorageKey {
+			return ErrConflict
+		}
+	}
+
+	r.lastID++
+	attachment.ID = r.lastID
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now()
+	}
+	r.attachments[attachment.ID] = *attachment
+	return nil
+}
+
+func (r *MemoryAttachmentRepository) Delete(ctx context.Context, attachment model.TaskAttachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attachments, attachment.ID)
+	return nil
+}
+
+func (r *MemoryAttachmentRepository) withUploader(attachment model.TaskAttachment) model.TaskAttachment {
+	if uploader, ok := r.users.lookup(attachment.UploadedBy); ok {
+		attachment.Uploader = uploader
+	}
+	return attachment
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+type MemoryCommentRepository struct {
+	mu       sync.Mutex
+	comments map[uint]model.TaskComment
+	lastID   uint
+	users    *MemoryUserRepository
+}
+
+func NewMemoryCommentRepository(users *MemoryUserRepository, comments ...model.TaskComment) *MemoryCommentRepository {
+	r := &MemoryCommentRepository{comments: map[uint]model.TaskComment{}, users: users}
+	for _, comment := range comments {
+		if comment.ID > r.lastID {
+			r.lastID = comment.ID
+		}
+		r.comments[comment.ID] = comment
+	}
+	return r
+}
+
+func (r *MemoryCommentRepository) Find(ctx context.Context, taskID uint, id uint) (model.TaskComment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comment, ok := r.comments[id]
+	if !ok || comment.TaskID != taskID {
+		return model.TaskComment{}, ErrNotFound
+	}
+	return r.withAuthor(comment), nil
+}
+
+func (r *MemoryCommentRepository) Threads(ctx context.Context, taskID uint, offset int, limit int) ([]model.TaskComment, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var threads []model.TaskComment
+	replies := map[uint][]model.TaskComment{}
+	for _, comment := range r.comments {
+		if comment.TaskID != taskID {
+			continue
+		}
+		if comment.ParentID == nil {
+			threads = append(threads, r.withAuthor(comment))
+		} else {
+			replies[*comment.ParentID] = append(replies[*comment.ParentID], r.withAuthor(comment))
+		}
+	}
+
+	sortComments(threads)
+	total := int64(len(threads))
+	threads = pageOf(threads, offset, limit)
+	for i := range threads {
+		threads[i].Replies = replies[threads[i].ID]
+		sortComments(threads[i].Replies)
+	}
+	return threads, total, nil
+}
+
+func (r *MemoryCommentRepository) Create(ctx context.Context, comment *model.TaskComment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	comment.ID = r.lastID
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = time.Now()
+	}
+	r.comments[comment.ID] = *comment
+	return nil
+}
+
+func (r *MemoryCommentRepository) Update(ctx context.Context, comment model.TaskComment, body string, editedAt time.Time, mentions []model.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.comments[comment.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	stored.Body = body
+	stored.EditedAt = &editedAt
+	stored.Mentions = append([]model.User(nil), mentions...)
+	r.comments[comment.ID] = stored
+	return nil
+}
+
+func (r *MemoryCommentRepository) Delete(ctx context.Context, comment model.TaskComment, deletedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.comments[comment.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	stored.Body = ""
+	stored.Mentions = nil
+	stored.DeletedAt = &deletedAt
+	r.comments[comment.ID] = stored
+	return nil
+}
+
+func (r *MemoryCommentRepository) withAuthor(comment model.TaskComment) model.TaskComment {
+	if author, ok := r.users.lookup(comment.AuthorID); ok {
+		comment.Author = author
+	}
+	comment.Mentions = append([]model.User{}, comment.Mentions...)
+	return comment
+}
+
+func sortComments(comments []model.TaskComment) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+		}
+		return comments[i].ID < comments[j].ID
+	})
+}
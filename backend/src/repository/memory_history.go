@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+type MemoryHistoryRepository struct {
+	tasks *MemoryTaskRepository
+	users *MemoryUserRepository
+}
+
+func NewMemoryHistoryRepository(tasks *MemoryTaskRepository, users *MemoryUserRepository) *MemoryHistoryRepository {
+	return &MemoryHistoryRepository{tasks: tasks, users: users}
+}
+
+func (r *MemoryHistoryRepository) List(ctx context.Context, query HistoryQuery) ([]model.TaskHistory, int64, error) {
+	var histories []model.TaskHistory
+	for _, history := range r.tasks.Histories(query.TaskID) {
+		if query.Field != "" && !slices.ContainsFunc(history.Changes, func(change model.TaskHistoryChange) bool {
+			return change.Field == query.Field
+		}) {
+			continue
+		}
+		history.ActionUser = r.users.lookupRef(history.ActionBy)
+		histories = append(histories, history)
+	}
+
+	sort.SliceStable(histories, func(i, j int) bool {
+		a, b := histories[i], histories[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt) != query.Desc
+		}
+		return (a.ID < b.ID) != query.Desc
+	})
+
+	return pageOf(histories, query.Offset, query.Limit), int64(len(histories)), nil
+}
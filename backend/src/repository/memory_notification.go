@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+type MemoryNotificationRepository struct {
+	mu            sync.Mutex
+	notifications map[uint]model.Notification
+	users         *MemoryUserRepository
+}
+
+func NewMemoryNotificationRepository(users *MemoryUserRepository, notifications ...model.Notification) *MemoryNotificationRepository {
+	r := &MemoryNotificationRepository{notifications: map[uint]model.Notification{}, users: users}
+	for _, notification := range notifications {
+		r.notifications[notification.ID] = notification
+	}
+	return r
+}
+
+func (r *MemoryNotificationRepository) List(ctx context.Context, query NotificationQuery) ([]model.Notification, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var items []model.Notification
+	for _, notification := range r.notifications {
+		if notification.UserID != query.UserID {
+			continue
+		}
+		if query.Unread != nil && (notification.ReadAt == nil) != *query.Unread {
+			continue
+		}
+		notification.Actor = r.users.lookupRef(notification.ActorID)
+		items = append(items, notification)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].CreatedAt.Equal(items[j].CreatedAt) {
+			return items[i].CreatedAt.After(items[j].CreatedAt)
+		}
+		return items[i].ID > items[j].ID
+	})
+
+	return pageOf(items, query.Offset, query.Limit), int64(len(items)), nil
+}
+
+func (r *MemoryNotificationRepository) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	unread := true
+	_, total, err := r.List(ctx, NotificationQuery{UserID: userID, Unread: &unread})
+	return total, err
+}
+
+func (r *MemoryNotificationRepository) Find(ctx context.Context, userID uint, id uint) (model.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notification, ok := r.notifications[id]
+	if !ok || notification.UserID != userID {
+		return model.Notification{}, ErrNotFound
+	}
+	return notification, nil
+}
+
+func (r *MemoryNotificationRepository) MarkRead(ctx context.Context, id uint, readAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if notification, ok := r.notifications[id]; ok && notification.ReadAt == nil {
+		notification.ReadAt = &readAt
+		r.notifications[id] = notification
+	}
+	return nil
+}
+
+func (r *MemoryNotificationRepository) MarkAllRead(ctx context.Context, userID uint, readAt time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var updated int64
+	for id, notification := range r.notifications {
+		if notification.UserID == userID && notification.ReadAt == nil {
+			notification.ReadAt = &readAt
+			r.notifications[id] = notification
+			updated++
+		}
+	}
+	return updated, nil
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+type MemorySessionRepository struct {
+	mu       sync.Mutex
+	sessions map[uint]model.Session
+	tokens   map[uint]model.RefreshToken
+	lastID   uint
+	tokenID  uint
+	users    *MemoryUserRepository
+}
+
+func NewMemorySessionRepository(users *MemoryUserRepository) *MemorySessionRepository {
+	return &MemorySessionRepository{
+		sessions: map[uint]model.Session{},
+		tokens:   map[uint]model.RefreshToken{},
+		users:    users,
+	}
+}
+
+func (r *MemorySessionRepository) Create(ctx context.Context, session *model.Session, token *model.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	session.ID = r.lastID
+	session.CreatedAt = time.Now()
+	r.sessions[session.ID] = *session
+
+	token.SessionID = session.ID
+	r.appendToken(token)
+	return nil
+}
+
+func (r *MemorySessionRepository) Find(ctx context.Context, id uint) (model.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return model.Session{}, ErrNotFound
+	}
+	return r.withUser(session), nil
+}
+
+func (r *MemorySessionRepository) FindRefreshToken(ctx context.Context, tokenHash string) (model.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			token.Session = r.withUser(r.sessions[token.SessionID])
+			return token, nil
+		}
+	}
+	return model.RefreshToken{}, ErrNotFound
+}
+
+func (r *MemorySessionRepository) Rotate(ctx context.Context, used model.RefreshToken, usedAt time.Time, next *model.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tokens[used.ID]
+	if !ok || stored.UsedAt != nil {
+		return ErrConflict
+	}
+	stored.UsedAt = &usedAt
+	r.tokens[used.ID] = stored
+
+	session := r.sessions[used.SessionID]
+	session.ExpiresAt = next.ExpiresAt
+	r.sessions[used.SessionID] = session
+
+	next.SessionID = used.SessionID
+	r.appendToken(next)
+	return nil
+}
+
+func (r *MemorySessionRepository) Revoke(ctx context.Context, id uint, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if session, ok := r.sessions[id]; ok && session.RevokedAt == nil {
+		session.RevokedAt = &revokedAt
+		r.sessions[id] = session
+	}
+	return nil
+}
+
+func (r *MemorySessionRepository) RevokeUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil {
+			session.RevokedAt = &revokedAt
+			r.sessions[id] = session
+		}
+	}
+	return nil
+}
+
+func (r *MemorySessionRepository) appendToken(token *model.RefreshToken) {
+	r.tokenID++
+	token.ID = r.tokenID
+	token.CreatedAt = time.Now()
+	r.tokens[token.ID] = *token
+}
+
+func (r *MemorySessionRepository) withUser(session model.Session) model.Session {
+	if user, ok := r.users.lookup(session.UserID); ok {
+		session.User = user
+	}
+	return session
+}
+
+type MemoryPasswordResetRepository struct {
+	mu     sync.Mutex
+	tokens map[uint]model.PasswordResetToken
+	lastID uint
+	users  *MemoryUserRepository
+}
+
+func NewMemoryPasswordResetRepository(users *MemoryUserRepository) *MemoryPasswordResetRepository {
+	return &MemoryPasswordResetRepository{tokens: map[uint]model.PasswordResetToken{}, users: users}
+}
+
+func (r *MemoryPasswordResetRepository) Issue(ctx context.Context, token *model.PasswordResetToken, audit *model.UserAudit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for id, existing := range r.tokens {
+		if existing.UserID == token.UserID && existing.UsedAt == nil {
+			existing.UsedAt = &now
+			r.tokens[id] = existing
+		}
+	}
+
+	r.lastID++
+	token.ID = r.lastID
+	token.CreatedAt = now
+	r.tokens[token.ID] = *token
+
+	if audit != nil {
+		audit.UserID = token.UserID
+		r.users.addAudit(audit)
+	}
+	return nil
+}
+
+func (r *MemoryPasswordResetRepository) FindByHash(ctx context.Context, tokenHash string) (model.PasswordResetToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			if user, ok := r.users.lookup(token.UserID); ok {
+				token.User = user
+			}
+			return token, nil
+		}
+	}
+	return model.PasswordResetToken{}, ErrNotFound
+}
+
+func (r *MemoryPasswordResetRepository) Redeem(ctx context.Context, token model.PasswordResetToken, passwordHash string, usedAt time.Time) error {
+	r.mu.Lock()
+	stored, ok := r.tokens[token.ID]
+	if !ok || stored.UsedAt != nil {
+		r.mu.Unlock()
+		return ErrConflict
+	}
+	stored.UsedAt = &usedAt
+	r.tokens[token.ID] = stored
+	r.mu.Unlock()
+
+	return r.users.Update(ctx, model.User{ID: token.UserID}, UserChange{
+		Updates:        map[string]interface{}{"password": passwordHash},
+		RevokeSessions: true,
+	})
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+type MemoryWebhookRepository struct {
+	mu         sync.Mutex
+	hooks      map[uint]model.Webhook
+	deliveries map[uint]model.WebhookDelivery
+	lastID     uint
+	deliveryID uint
+	users      *MemoryUserRepository
+}
+
+func NewMemoryWebhookRepository(users *MemoryUserRepository, hooks ...model.Webhook) *MemoryWebhookRepository {
+	r := &MemoryWebhookRepository{
+		hooks:      map[uint]model.Webhook{},
+		deliveries: map[uint]model.WebhookDelivery{},
+		users:      users,
+	}
+	for _, hook := range hooks {
+		if hook.ID > r.lastID {
+			r.lastID = hook.ID
+		}
+		r.hooks[hook.ID] = hook
+	}
+	return r
+}
+
+func (r *MemoryWebhookRepository) List(ctx context.Context) ([]model.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var hooks []model.Webhook
+	for _, hook := range r.hooks {
+		hooks = append(hooks, r.withCreator(hook))
+	}
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].ID < hooks[j].ID })
+	return hooks, nil
+}
+
+func (r *MemoryWebhookRepository) Find(ctx context.Context, id uint) (model.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hook, ok := r.hooks[id]
+	if !ok {
+		return model.Webhook{}, ErrNotFound
+	}
+	return r.withCreator(hook), nil
+}
+
+func (r *MemoryWebhookRepository) Create(ctx context.Context, hook *model.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	hook.ID = r.lastID
+	now := time.Now()
+	hook.CreatedAt, hook.UpdatedAt = now, now
+	r.hooks[hook.ID] = *hook
+	return nil
+}
+
+func (r *MemoryWebhookRepository) Update(ctx context.Context, hook model.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.hooks[hook.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	stored.URL = hook.URL
+	stored.Events = append([]string(nil), hook.Events...)
+	stored.Description = hook.Description
+	stored.Active = hook.Active
+	stored.UpdatedAt = time.Now()
+	r.hooks[hook.ID] = stored
+	return nil
+}
+
+func (r *MemoryWebhookRepository) RotateSecret(ctx context.Context, id uint, secret string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.hooks[id]
+	if !ok {
+		return ErrNotFound
+	}
+	stored.Secret = secret
+	stored.UpdatedAt = time.Now()
+	r.hooks[id] = stored
+	return nil
+}
+
+func (r *MemoryWebhookRepository) Delete(ctx context.Context, hook model.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, delivery := range r.deliveries {
+		if delivery.WebhookID == hook.ID {
+			delete(r.deliveries, id)
+		}
+	}
+	delete(r.hooks, hook.ID)
+	return nil
+}
+
+func (r *MemoryWebhookRepository) Deliveries(ctx context.Context, query DeliveryQuery) ([]model.WebhookDelivery, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var items []model.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookID == query.WebhookID && matchesDeliveryStatus(delivery, query.Status) {
+			items = append(items, delivery)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID > items[j].ID })
+
+	return pageOf(items, query.Offset, query.Limit), int64(len(items)), nil
+}
+
+func matchesDeliveryStatus(delivery model.WebhookDelivery, status string) bool {
+	switch status {
+	case "pending":
+		return delivery.DeliveredAt == nil && delivery.FailedAt == nil
+	case "delivered":
+		return delivery.DeliveredAt != nil
+	case "failed":
+		return delivery.FailedAt != nil
+	}
+	return true
+}
+
+func (r *MemoryWebhookRepository) FindDelivery(ctx context.Context, webhookID uint, id uint) (model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery, ok := r.deliveries[id]
+	if !ok || delivery.WebhookID != webhookID {
+		return model.WebhookDelivery{}, ErrNotFound
+	}
+	return delivery, nil
+}
+
+func (r *MemoryWebhookRepository) CreateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.hooks[delivery.WebhookID]; !ok {
+		return ErrNotFound
+	}
+
+	r.deliveryID++
+	delivery.ID = r.deliveryID
+	now := time.Now()
+	delivery.CreatedAt, delivery.UpdatedAt = now, now
+	r.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (r *MemoryWebhookRepository) withCreator(hook model.Webhook) model.Webhook {
+	if creator, ok := r.users.lookup(hook.CreatedBy); ok {
+		hook.Creator = creator
+	}
+	return hook
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+var (
+	ErrNotFound = errors.New("record not found")
+	ErrConflict = errors.New("record was modified concurrently")
+)
+
+type TaskScope struct {
+	CreatedBy      uint
+	AssignedLeader uint
+	Statuses       []string
+	OrEscalated    bool
+}
+
+type TaskSort struct {
+	Column string
+	Desc   bool
+}
+
+type TaskQuery struct {
+	Scope          TaskScope
+	Statuses       []string
+	LeaderID       uint
+	CreatedBy      uint
+	DeadlineFrom   *time.Time
+	DeadlineBefore *time.Time
+	DeadlineUntil  *time.Time
+	ProgressMin    *int
+	ProgressMax    *int
+	Overdue        *bool
+	Escalated      *bool
+	Search         string
+	Sort           []TaskSort
+	Offset         int
+	Limit          int
+	WithHistories  bool
+}
+
+type TaskRepository interface {
+	WithinTx(ctx context.Context, fn func(TaskRepository) error) error
+	Find(ctx context.Context, id uint) (model.Task, error)
+	FindForUpdate(ctx context.Context, id uint) (model.Task, error)
+	FindWithHistories(ctx context.Context, id uint) (model.Task, error)
+	FindDetail(ctx context.Context, id uint) (model.Task, error)
+	List(ctx context.Context, query TaskQuery) ([]model.Task, int64, error)
+	Create(ctx context.Context, task *model.Task, history *model.TaskHistory) error
+	Update(ctx context.Context, task model.Task, changes map[string]interface{}, history *model.TaskHistory) error
+	Delete(ctx context.Context, task model.Task, history *model.TaskHistory) error
+}
+
+type UserFilter struct {
+	Role   string
+	Active *bool
+}
+
+type UserChange struct {
+	Updates        map[string]interface{}
+	RevokeSessions bool
+	Audit          *model.UserAudit
+}
+
+type UserRepository interface {
+	Find(ctx context.Context, id uint) (model.User, error)
+	FindByUsername(ctx context.Context, username string) (model.User, error)
+	FindActive(ctx context.Context, id uint, role string) (model.User, error)
+	ActiveByRole(ctx context.Context, role string) ([]model.User, error)
+	ActiveIDsByRole(ctx context.Context, role string) ([]uint, error)
+	ActiveByUsernames(ctx context.Context, usernames []string) ([]model.User, error)
+	List(ctx context.Context, filter UserFilter) ([]model.User, error)
+	UsernameTaken(ctx context.Context, username string, exceptID uint) (bool, error)
+	Create(ctx context.Context, user *model.User, audit *model.UserAudit) error
+	Update(ctx context.Context, user model.User, change UserChange) error
+	Audits(ctx context.Context, userID uint) ([]model.UserAudit, error)
+}
+
+type HistoryQuery struct {
+	TaskID uint
+	Field  string
+	Desc   bool
+	Offset int
+	Limit  int
+}
+
+type HistoryRepository interface {
+	List(ctx context.Context, query HistoryQuery) ([]model.TaskHistory, int64, error)
+}
+
+type CommentRepository interface {
+	Find(ctx context.Context, taskID uint, id uint) (model.TaskComment, error)
+	Threads(ctx context.Context, taskID uint, offset int, limit int) ([]model.TaskComment, int64, error)
+	Create(ctx context.Context, comment *model.TaskComment) error
+	Update(ctx context.Context, comment model.TaskComment, body string, editedAt time.Time, mentions []model.User) error
+	Delete(ctx context.Context, comment model.TaskComment, deletedAt time.Time) error
+}
+
+type AttachmentRepository interface {
+	Find(ctx context.Context, taskID uint, id uint) (model.TaskAttachment, error)
+	ListByTask(ctx context.Context, taskID uint) ([]model.TaskAttachment, error)
+	StorageKeys(ctx context.Context, taskID uint) ([]string, error)
+	Create(ctx context.Context, attachment *model.TaskAttachment) error
+	Delete(ctx context.Context, attachment model.TaskAttachment) error
+}
+
+type NotificationQuery struct {
+	UserID uint
+	Unread *bool
+	Offset int
+	Limit  int
+}
+
+type NotificationRepository interface {
+	List(ctx context.Context, query NotificationQuery) ([]model.Notification, int64, error)
+	CountUnread(ctx context.Context, userID uint) (int64, error)
+	Find(ctx context.Context, userID uint, id uint) (model.Notification, error)
+	MarkRead(ctx context.Context, id uint, readAt time.Time) error
+	MarkAllRead(ctx context.Context, userID uint, readAt time.Time) (int64, error)
+}
+
+type DeliveryQuery struct {
+	WebhookID uint
+	Status    string
+	Offset    int
+	Limit     int
+}
+
+type WebhookRepository interface {
+	List(ctx context.Context) ([]model.Webhook, error)
+	Find(ctx context.Context, id uint) (model.Webhook, error)
+	Create(ctx context.Context, hook *model.Webhook) error
+	Update(ctx context.Context, hook model.Webhook) error
+	RotateSecret(ctx context.Context, id uint, secret string) error
+	Delete(ctx context.Context, hook model.Webhook) error
+	Deliveries(ctx context.Context, query DeliveryQuery) ([]model.WebhookDelivery, int64, error)
+	FindDelivery(ctx context.Context, webhookID uint, id uint) (model.WebhookDelivery, error)
+	CreateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *model.Session, token *model.RefreshToken) error
+	Find(ctx context.Context, id uint) (model.Session, error)
+	FindRefreshToken(ctx context.Context, tokenHash string) (model.RefreshToken, error)
+	Rotate(ctx context.Context, used model.RefreshToken, usedAt time.Time, next *model.RefreshToken) error
+	Revoke(ctx context.Context, id uint, revokedAt time.Time) error
+	RevokeUser(ctx context.Context, userID uint, revokedAt time.Time) error
+}
+
+type PasswordResetRepository interface {
+	Issue(ctx context.Context, token *model.PasswordResetToken, audit *model.UserAudit) error
+	FindByHash(ctx context.Context, tokenHash string) (model.PasswordResetToken, error)
+	Redeem(ctx context.Context, token model.PasswordResetToken, passwordHash string, usedAt time.Time) error
+}
+
+type Repositories struct {
+	Tasks          TaskRepository
+	Users          UserRepository
+	Histories      HistoryRepository
+	Comments       CommentRepository
+	Attachments    AttachmentRepository
+	Notifications  NotificationRepository
+	Webhooks       WebhookRepository
+	Sessions       SessionRepository
+	PasswordResets PasswordResetRepository
+}
+
+var Default Repositories
@@ -1,6 +1,7 @@
 package routers
 
 import (
+	"github.com/ardhia137/task_todo/src/config"
 	"github.com/ardhia137/task_todo/src/handlers"
 	"github.com/ardhia137/task_todo/src/middleware"
 
@@ -9,18 +10,26 @@ import (
 )
 
 func SetupRouter() *gin.Engine {
-	r := gin.Default()
+	r := gin.New()
+	r.Use(middleware.RequestID(), middleware.RequestLogger(), middleware.Recovery())
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
+		AllowOrigins:     config.Current.CORS.AllowOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "If-Match", "Last-Event-ID", middleware.RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", "ETag", middleware.RequestIDHeader},
 		AllowCredentials: true,
 	}))
 
+	r.GET("/healthz", handlers.Healthz)
+	r.GET("/readyz", handlers.Readyz)
+
 	authGroup := r.Group("/auth")
 	{
 		authGroup.POST("/login", handlers.LoginHandler)
+		authGroup.POST("/refresh", handlers.RefreshTokenHandler)
+		authGroup.POST("/logout", middleware.AuthMiddleware(), handlers.LogoutHandler)
+		authGroup.PUT("/password", middleware.AuthMiddleware(), handlers.ChangePasswordHandler)
+		authGroup.POST("/password/reset", handlers.ResetPasswordHandler)
 	}
 
 	leaderGroup := r.Group("/leader")
@@ -29,9 +38,76 @@ func SetupRouter() *gin.Engine {
 		leaderGroup.GET("/", handlers.GetLeader)
 	}
 
+	userGroup := r.Group("/users")
+	userGroup.Use(middleware.AuthMiddleware(), middleware.RequireManager())
+	{
+		userGroup.GET("/", handlers.GetUsers)
+		userGroup.POST("/", handlers.CreateUser)
+		userGroup.GET("/:id", handlers.GetUser)
+		userGroup.PUT("/:id", handlers.UpdateUser)
+		userGroup.PUT("/:id/role", handlers.ChangeUserRole)
+		userGroup.PUT("/:id/deactivate", handlers.DeactivateUser)
+		userGroup.PUT("/:id/activate", handlers.ActivateUser)
+		userGroup.GET("/:id/audits", handlers.GetUserAudits)
+		userGroup.POST("/:id/password-reset", handlers.IssuePasswordResetHandler)
+	}
+
+	webhookGroup := r.Group("/webhooks")
+	webhookGroup.Use(middleware.AuthMiddleware(), middleware.RequireManager())
+	{
+		webhookGroup.GET("/", handlers.GetWebhooks)
+		webhookGroup.POST("/", handlers.CreateWebhook)
+		webhookGroup.GET("/:id", handlers.GetWebhook)
+		webhookGroup.PUT("/:id", handlers.UpdateWebhook)
+		webhookGroup.DELETE("/:id", handlers.DeleteWebhook)
+		webhookGroup.POST("/:id/secret", handlers.RotateWebhookSecret)
+		webhookGroup.GET("/:id/deliveries", handlers.GetWebhookDeliveries)
+		webhookGroup.POST("/:id/deliveries/:deliveryId/redeliver", handlers.RedeliverWebhook)
+	}
+
+	workflowGroup := r.Group("/workflow")
+	workflowGroup.Use(middleware.AuthMiddleware())
+	{
+		workflowGroup.GET("/", handlers.GetWorkflow)
+	}
+
+	eventGroup := r.Group("/events")
+	eventGroup.Use(middleware.TokenFromQuery(), middleware.AuthMiddleware())
+	{
+		eventGroup.GET("/stream", handlers.StreamEvents)
+	}
+
+	notificationGroup := r.Group("/notifications")
+	notificationGroup.Use(middleware.AuthMiddleware())
+	{
+		notificationGroup.GET("/", handlers.GetNotifications)
+		notificationGroup.GET("/unread-count", handlers.GetUnreadNotificationCount)
+		notificationGroup.PUT("/read-all", handlers.MarkAllNotificationsRead)
+		notificationGroup.PUT("/:id/read", handlers.MarkNotificationRead)
+	}
+
 	taskGroup := r.Group("/tasks")
 	taskGroup.Use(middleware.AuthMiddleware())
 	{
+		taskGroup.GET("/:id", handlers.GetTaskDetail)
+		taskGroup.GET("/:id/histories", handlers.GetTaskHistories)
+		taskGroup.POST("/:id/transitions", handlers.TransitionTask)
+
+		commentGroup := taskGroup.Group("/:id/comments")
+		{
+			commentGroup.GET("", middleware.RequireRole("pelaksana", "leader", "manager"), handlers.GetTaskComments)
+			commentGroup.POST("", middleware.RequirePelaksanaOrLeader(), handlers.CreateTaskComment)
+			commentGroup.PUT("/:commentId", middleware.RequirePelaksanaOrLeader(), handlers.UpdateTaskComment)
+			commentGroup.DELETE("/:commentId", middleware.RequirePelaksanaOrLeader(), handlers.DeleteTaskComment)
+		}
+
+		attachmentGroup := taskGroup.Group("/:id/attachments")
+		{
+			attachmentGroup.GET("", handlers.GetTaskAttachments)
+			attachmentGroup.GET("/:attachmentId/download", handlers.DownloadTaskAttachment)
+			attachmentGroup.POST("", middleware.RequirePelaksanaOrLeader(), handlers.UploadTaskAttachment)
+			attachmentGroup.DELETE("/:attachmentId", middleware.RequirePelaksanaOrLeader(), handlers.DeleteTaskAttachment)
+		}
 
 		pelaksanaGroup := taskGroup.Group("")
 		pelaksanaGroup.Use(middleware.RequirePelaksana())
@@ -56,6 +132,10 @@ func SetupRouter() *gin.Engine {
 		managerGroup.Use(middleware.RequireManager())
 		{
 			managerGroup.GET("/approved", handlers.GetTaskManager)
+			managerGroup.PUT("/:id/manager-approve", handlers.ManagerApproveTask)
+			managerGroup.PUT("/:id/return", handlers.ReturnTaskToLeader)
+			managerGroup.PUT("/:id/reject", handlers.RejectTask)
+			managerGroup.PUT("/:id/reassign", handlers.ReassignTask)
 		}
 	}
 
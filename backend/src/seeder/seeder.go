@@ -1,7 +1,7 @@
 package seed
 
 import (
-	"log"
+	"log/slog"
 
 	"github.com/ardhia137/task_todo/src/model"
 	"golang.org/x/crypto/bcrypt"
@@ -25,8 +25,8 @@ func SeedUsers(db *gorm.DB) {
 		hash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
 		users[i].Password = string(hash)
 		if err := db.Create(&users[i]).Error; err != nil {
-			log.Printf("seed user failed: %v", err)
+			slog.Error("seed user failed", "error", err)
 		}
 	}
-	log.Println("seed users done. default password: password123")
+	slog.Info("seed users done", "default_password", "password123")
 }
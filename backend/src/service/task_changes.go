@@ -0,0 +1,53 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/model"
+)
+
+var trackedTaskFields = []struct {
+	column string
+	value  func(model.Task) interface{}
+}{
+	{"title", func(t model.Task) interface{} { return t.Title }},
+	{"description", func(t model.Task) interface{} { return t.Description }},
+	{"assigned_leader", func(t model.Task) interface{} { return t.AssignedLeader }},
+	{"deadline", func(t model.Task) interface{} { return t.Deadline }},
+	{"status", func(t model.Task) interface{} { return t.Status }},
+	{"progress", func(t model.Task) interface{} { return t.Progress }},
+}
+
+func diffTask(task model.Task, changes map[string]interface{}) []model.TaskHistoryChange {
+	var diff []model.TaskHistoryChange
+	for _, field := range trackedTaskFields {
+		value, ok := changes[field.column]
+		if !ok {
+			continue
+		}
+
+		oldValue := formatChangeValue(field.value(task))
+		newValue := formatChangeValue(value)
+		if oldValue != newValue {
+			diff = append(diff, model.TaskHistoryChange{Field: field.column, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	return diff
+}
+
+func formatChangeValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,401 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/escalation"
+	"github.com/ardhia137/task_todo/src/logging"
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/notify"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/workflow"
+)
+
+var (
+	ErrVersionMismatch    = errors.New("task version does not match")
+	ErrNotEscalated       = errors.New("only escalated tasks can be reassigned")
+	ErrReassignNotAllowed = errors.New("escalation rule does not allow reassignment")
+	ErrSameLeader         = errors.New("task is already assigned to this leader")
+	ErrLeaderNotFound     = errors.New("leader not found or inactive")
+)
+
+type WorkflowError struct {
+	Err    error
+	Status string
+}
+
+func (e *WorkflowError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *WorkflowError) Unwrap() error {
+	return e.Err
+}
+
+type TaskService struct {
+	Tasks      repository.TaskRepository
+	Users      repository.UserRepository
+	Workflow   func() *workflow.Definition
+	Escalation func() *escalation.Config
+	Publish    func(ctx context.Context, event notify.Event)
+	Now        func() time.Time
+}
+
+var Default *TaskService
+
+func NewTaskService(tasks repository.TaskRepository, users repository.UserRepository) *TaskService {
+	return &TaskService{
+		Tasks:      tasks,
+		Users:      users,
+		Workflow:   func() *workflow.Definition { return workflow.Current },
+		Escalation: func() *escalation.Config { return escalation.Current },
+		Publish:    notify.Publish,
+		Now:        time.Now,
+	}
+}
+
+type NewTask struct {
+	Title       string
+	Description string
+	LeaderID    uint
+	Deadline    time.Time
+}
+
+type TransitionInput struct {
+	TaskID       uint
+	Actor        policy.Actor
+	Permission   policy.Action
+	Action       string
+	Note         string
+	Changes      map[string]interface{}
+	Precondition func(model.Task) error
+}
+
+type DeleteInput struct {
+	TaskID       uint
+	Actor        policy.Actor
+	Precondition func(model.Task) error
+}
+
+type ReassignInput struct {
+	TaskID       uint
+	Actor        policy.Actor
+	LeaderID     uint
+	Note         string
+	Precondition func(model.Task) error
+}
+
+func (s *TaskService) Get(ctx context.Context, actor policy.Actor, taskID uint, permission policy.Action) (model.Task, error) {
+	task, err := s.Tasks.Find(ctx, taskID)
+	if err != nil {
+		return model.Task{}, taskLookupError(err)
+	}
+
+	if err := policy.CanTask(actor, permission, &task); err != nil {
+		return model.Task{}, err
+	}
+	return task, nil
+}
+
+func (s *TaskService) Detail(ctx context.Context, actor policy.Actor, taskID uint) (model.Task, error) {
+	task, err := s.Tasks.FindDetail(ctx, taskID)
+	if err != nil {
+		return model.Task{}, taskLookupError(err)
+	}
+
+	if err := policy.CanTask(actor, policy.ActionView, &task); err != nil {
+		return model.Task{}, err
+	}
+	return task, nil
+}
+
+func (s *TaskService) List(ctx context.Context, actor policy.Actor, query repository.TaskQuery) ([]model.Task, int64, error) {
+	switch actor.Role {
+	case "pelaksana":
+		query.Scope = repository.TaskScope{CreatedBy: actor.ID}
+	case "leader":
+		statuses := s.Workflow().StatesFor("leader")
+		if len(statuses) == 0 {
+			return nil, 0, nil
+		}
+		query.Scope = repository.TaskScope{AssignedLeader: actor.ID, Statuses: statuses}
+	case "manager":
+		query.Scope = repository.TaskScope{Statuses: s.Workflow().StatesFor("manager"), OrEscalated: true}
+	default:
+		return nil, 0, policy.ErrForbidden
+	}
+
+	return s.Tasks.List(ctx, query)
+}
+
+func (s *TaskService) Leaders(ctx context.Context) ([]model.User, error) {
+	return s.Users.ActiveByRole(ctx, "leader")
+}
+
+func (s *TaskService) Create(ctx context.Context, actor policy.Actor, input NewTask) (model.Task, error) {
+	if _, err := s.activeLeader(ctx, input.LeaderID); err != nil {
+		return model.Task{}, err
+	}
+
+	task := model.Task{
+		Title:          input.Title,
+		Description:    input.Description,
+		CreatedBy:      actor.ID,
+		AssignedLeader: input.LeaderID,
+		Status:         s.Workflow().Initial,
+		Progress:       0,
+		ProgressBy:     actor.ID,
+		Deadline:       input.Deadline,
+	}
+	history := model.TaskHistory{
+		ActionBy: &actor.ID,
+		Action:   "submit",
+	}
+
+	if err := s.Tasks.Create(ctx, &task, &history); err != nil {
+		return model.Task{}, err
+	}
+
+	created, err := s.Tasks.FindWithHistories(ctx, task.ID)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	s.publishTaskEvent(ctx, history.Action, created, actor.ID, "")
+	return created, nil
+}
+
+func (s *TaskService) Transition(ctx context.Context, input TransitionInput) (model.Task, error) {
+	changes := map[string]interface{}{}
+	for column, value := range input.Changes {
+		changes[column] = value
+	}
+
+	var transition *workflow.Transition
+	var current model.Task
+	err := s.Tasks.WithinTx(ctx, func(tasks repository.TaskRepository) error {
+		var err error
+		current, err = tasks.FindForUpdate(ctx, input.TaskID)
+		if err != nil {
+			return taskLookupError(err)
+		}
+
+		if err := policy.CanTask(input.Actor, input.Permission, &current); err != nil {
+			return err
+		}
+
+		if input.Precondition != nil {
+			if err := input.Precondition(current); err != nil {
+				return err
+			}
+		}
+
+		if leaderID, ok := changes["assigned_leader"].(uint); ok && leaderID != current.AssignedLeader {
+			if _, err := s.activeLeader(ctx, leaderID); err != nil {
+				return err
+			}
+		}
+
+		transition, err = s.Workflow().Fire(current.Status, input.Action, input.Actor.Role, input.Note)
+		if err != nil {
+			return &WorkflowError{Err: err, Status: current.Status}
+		}
+
+		changes["status"] = transition.To
+		changes["version"] = current.Version + 1
+		if transition.To != current.Status {
+			changes["escalated_at"] = nil
+			changes["escalation_rule"] = ""
+		}
+
+		return s.update(ctx, tasks, current, changes, &model.TaskHistory{
+			ActionBy: &input.Actor.ID,
+			Action:   transition.Action,
+			Note:     input.Note,
+			Changes:  diffTask(current, changes),
+		})
+	})
+	if err != nil {
+		return current, err
+	}
+
+	updated, err := s.Tasks.FindWithHistories(ctx, current.ID)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	s.publishTaskEvent(ctx, transition.Action, updated, input.Actor.ID, input.Note)
+	return updated, nil
+}
+
+func (s *TaskService) Reassign(ctx context.Context, input ReassignInput) (model.Task, error) {
+	leader, err := s.activeLeader(ctx, input.LeaderID)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	note := input.Note
+	if note == "" {
+		note = fmt.Sprintf("Reassigned to %s", leader.Username)
+	}
+
+	var current model.Task
+	err = s.Tasks.WithinTx(ctx, func(tasks repository.TaskRepository) error {
+		var err error
+		current, err = tasks.FindForUpdate(ctx, input.TaskID)
+		if err != nil {
+			return taskLookupError(err)
+		}
+
+		if err := policy.CanTask(input.Actor, policy.ActionReassign, &current); err != nil {
+			return err
+		}
+
+		if input.Precondition != nil {
+			if err := input.Precondition(current); err != nil {
+				return err
+			}
+		}
+
+		if current.EscalatedAt == nil {
+			return ErrNotEscalated
+		}
+
+		rule, found := s.Escalation().Find(current.EscalationRule)
+		if !found || !rule.AllowReassign {
+			return ErrReassignNotAllowed
+		}
+
+		if leader.ID == current.AssignedLeader {
+			return ErrSameLeader
+		}
+
+		changes := map[string]interface{}{
+			"assigned_leader": leader.ID,
+			"escalated_at":    nil,
+			"escalation_rule": "",
+			"version":         current.Version + 1,
+		}
+		return s.update(ctx, tasks, current, changes, &model.TaskHistory{
+			ActionBy: &input.Actor.ID,
+			Action:   "reassign",
+			Note:     note,
+			Changes:  diffTask(current, changes),
+		})
+	})
+	if err != nil {
+		return current, err
+	}
+
+	updated, err := s.Tasks.FindWithHistories(ctx, current.ID)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	s.Publish(ctx, notify.Event{
+		Type:       "reassign",
+		Task:       updated,
+		ActorID:    &input.Actor.ID,
+		Note:       note,
+		Recipients: []uint{updated.CreatedBy, current.AssignedLeader, leader.ID},
+		OccurredAt: s.Now(),
+	})
+	return updated, nil
+}
+
+func (s *TaskService) Delete(ctx context.Context, input DeleteInput) (model.Task, error) {
+	var current model.Task
+	err := s.Tasks.WithinTx(ctx, func(tasks repository.TaskRepository) error {
+		var err error
+		current, err = tasks.FindForUpdate(ctx, input.TaskID)
+		if err != nil {
+			return taskLookupError(err)
+		}
+
+		if err := policy.CanTask(input.Actor, policy.ActionDelete, &current); err != nil {
+			return err
+		}
+
+		if input.Precondition != nil {
+			if err := input.Precondition(current); err != nil {
+				return err
+			}
+		}
+
+		if err := tasks.Delete(ctx, current, &model.TaskHistory{
+			ActionBy: &input.Actor.ID,
+			Action:   "delete",
+		}); err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				return ErrVersionMismatch
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return current, err
+	}
+
+	s.Publish(ctx, notify.Event{
+		Type:       "task_deleted",
+		Task:       current,
+		ActorID:    &input.Actor.ID,
+		Recipients: []uint{current.AssignedLeader},
+		OccurredAt: s.Now(),
+	})
+	return current, nil
+}
+
+func (s *TaskService) activeLeader(ctx context.Context, id uint) (model.User, error) {
+	leader, err := s.Users.FindActive(ctx, id, "leader")
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.User{}, ErrLeaderNotFound
+	}
+	return leader, err
+}
+
+func (s *TaskService) update(ctx context.Context, tasks repository.TaskRepository, current model.Task, changes map[string]interface{}, history *model.TaskHistory) error {
+	if err := tasks.Update(ctx, current, changes, history); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return ErrVersionMismatch
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *TaskService) publishTaskEvent(ctx context.Context, action string, task model.Task, actorID uint, note string) {
+	recipients := []uint{task.CreatedBy, task.AssignedLeader}
+
+	for _, state := range s.Workflow().States {
+		if state.Name == task.Status && slices.Contains(state.Roles, "manager") {
+			managers, err := s.Users.ActiveIDsByRole(ctx, "manager")
+			if err != nil {
+				logging.FromContext(ctx).Error("failed to load managers for task notification", "task_id", task.ID, "error", err)
+			}
+			recipients = append(recipients, managers...)
+		}
+	}
+
+	s.Publish(ctx, notify.Event{
+		Type:       action,
+		Task:       task,
+		ActorID:    &actorID,
+		Note:       note,
+		Recipients: recipients,
+		OccurredAt: s.Now(),
+	})
+}
+
+func taskLookupError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return policy.ErrTaskNotFound
+	}
+	return err
+}
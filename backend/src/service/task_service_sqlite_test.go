@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/config"
+	"github.com/ardhia137/task_todo/src/database"
+	"github.com/ardhia137/task_todo/src/migrations"
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/notify"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/workflow"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dialector, err := database.Dialector(config.Database{
+		Driver: "sqlite",
+		Name:   filepath.Join(t.TempDir(), "tasks.db"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if _, err := migrations.Up(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestTransitionConcurrentApproveAndRevise(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+
+	if err := workflow.Load(""); err != nil {
+		t.Fatal(err)
+	}
+
+	pelaksana := model.User{Username: "pelaksana", Password: "x", Role: "pelaksana", Active: true}
+	leader := model.User{Username: "leader", Password: "x", Role: "leader", Active: true}
+	for _, user := range []*model.User{&pelaksana, &leader} {
+		if err := db.Create(user).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc := NewTaskService(repository.NewGormTaskRepository(db), repository.NewGormUserRepository(db))
+	svc.Publish = func(context.Context, notify.Event) {}
+
+	task, err := svc.Create(ctx, policy.Actor{ID: pelaksana.ID, Role: "pelaksana"}, NewTask{
+		Title:    "Concurrent",
+		LeaderID: leader.ID,
+		Deadline: time.Now().Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	countHistories := func() int64 {
+		var count int64
+		if err := db.Model(&model.TaskHistory{}).Where("task_id = ?", task.ID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+	before := countHistories()
+
+	actions := []struct {
+		action string
+		note   string
+	}{
+		{action: "approve"},
+		{action: "revision", note: "Please revise"},
+	}
+
+	start := make(chan struct{})
+	errs := make([]error, len(actions))
+	var wg sync.WaitGroup
+	for i, a := range actions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, errs[i] = svc.Transition(ctx, TransitionInput{
+				TaskID:     task.ID,
+				Actor:      policy.Actor{ID: leader.ID, Role: "leader"},
+				Permission: policy.ActionTransition,
+				Action:     a.action,
+				Note:       a.note,
+				Precondition: func(current model.Task) error {
+					if current.Version != task.Version {
+						return ErrVersionMismatch
+					}
+					return nil
+				},
+			})
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded, mismatched := 0, 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, ErrVersionMismatch):
+			mismatched++
+		default:
+			t.Errorf("%s: unexpected error %v", actions[i].action, err)
+		}
+	}
+	if succeeded != 1 || mismatched != 1 {
+		t.Fatalf("got %d successes and %d version mismatches, want 1 and 1", succeeded, mismatched)
+	}
+
+	if after := countHistories(); after != before+1 {
+		t.Fatalf("history count = %d, want %d", after, before+1)
+	}
+
+	var stored model.Task
+	if err := db.First(&stored, task.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Version != task.Version+1 {
+		t.Fatalf("version = %d, want %d", stored.Version, task.Version+1)
+	}
+}
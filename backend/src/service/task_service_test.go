@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/escalation"
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/notify"
+	"github.com/ardhia137/task_todo/src/policy"
+	"github.com/ardhia137/task_todo/src/repository"
+	"github.com/ardhia137/task_todo/src/workflow"
+)
+
+const (
+	pelaksanaID      = 1
+	leaderID         = 2
+	otherLeaderID    = 3
+	managerID        = 4
+	inactiveLeaderID = 5
+)
+
+var testNow = time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+
+type staleTaskRepository struct {
+	repository.TaskRepository
+}
+
+func (r staleTaskRepository) WithinTx(ctx context.Context, fn func(repository.TaskRepository) error) error {
+	return r.TaskRepository.WithinTx(ctx, func(tasks repository.TaskRepository) error {
+		return fn(staleTaskRepository{tasks})
+	})
+}
+
+func (r staleTaskRepository) FindForUpdate(ctx context.Context, id uint) (model.Task, error) {
+	task, err := r.TaskRepository.FindForUpdate(ctx, id)
+	task.Version--
+	return task, err
+}
+
+func newTestService(t *testing.T, tasks ...model.Task) (*TaskService, *repository.MemoryTaskRepository, *[]notify.Event) {
+	t.Helper()
+
+	if err := workflow.Load(""); err != nil {
+		t.Fatal(err)
+	}
+	if err := escalation.Load(""); err != nil {
+		t.Fatal(err)
+	}
+
+	taskRepo := repository.NewMemoryTaskRepository(tasks...)
+	userRepo := repository.NewMemoryUserRepository(
+		model.User{ID: pelaksanaID, Username: "pelaksana", Role: "pelaksana", Active: true},
+		model.User{ID: leaderID, Username: "leader", Role: "leader", Active: true},
+		model.User{ID: otherLeaderID, Username: "leader2", Role: "leader", Active: true},
+		model.User{ID: managerID, Username: "manager", Role: "manager", Active: true},
+		model.User{ID: inactiveLeaderID, Username: "inactive", Role: "leader", Active: false},
+	)
+
+	var events []notify.Event
+	svc := NewTaskService(taskRepo, userRepo)
+	svc.Publish = func(ctx context.Context, event notify.Event) { events = append(events, event) }
+	svc.Now = func() time.Time { return testNow }
+	return svc, taskRepo, &events
+}
+
+func storedTask(status string) model.Task {
+	return model.Task{
+		ID:             1,
+		Title:          "Task",
+		CreatedBy:      pelaksanaID,
+		AssignedLeader: leaderID,
+		Status:         status,
+		Version:        3,
+		Deadline:       testNow.Add(48 * time.Hour),
+	}
+}
+
+func actorFor(role string) policy.Actor {
+	switch role {
+	case "pelaksana":
+		return policy.Actor{ID: pelaksanaID, Role: role}
+	case "leader":
+		return policy.Actor{ID: leaderID, Role: role}
+	}
+	return policy.Actor{ID: managerID, Role: role}
+}
+
+func TestCreate(t *testing.T) {
+	svc, tasks, events := newTestService(t)
+	ctx := context.Background()
+
+	task, err := svc.Create(ctx, actorFor("pelaksana"), NewTask{
+		Title:    "New task",
+		LeaderID: leaderID,
+		Deadline: testNow.Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if task.Status != workflow.Current.Initial || task.Version != 1 || task.CreatedBy != pelaksanaID {
+		t.Fatalf("created task = %+v", task)
+	}
+	if histories := tasks.Histories(task.ID); len(histories) != 1 || histories[0].Action != "submit" {
+		t.Fatalf("histories = %+v, want one submit", histories)
+	}
+	if len(*events) != 1 || (*events)[0].Type != "submit" {
+		t.Fatalf("events = %+v, want one submit", *events)
+	}
+
+	for _, id := range []uint{inactiveLeaderID, managerID, 99} {
+		if _, err := svc.Create(ctx, actorFor("pelaksana"), NewTask{Title: "x", LeaderID: id}); !errors.Is(err, ErrLeaderNotFound) {
+			t.Fatalf("Create(leader %d) error = %v, want %v", id, err, ErrLeaderNotFound)
+		}
+	}
+}
+
+func TestTransitionWorkflowEdges(t *testing.T) {
+	if err := workflow.Load(""); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, transition := range workflow.Current.Transitions {
+		for _, from := range transition.From {
+			for _, role := range transition.Roles {
+				t.Run(transition.Action+"/"+from+"/"+role, func(t *testing.T) {
+					svc, tasks, events := newTestService(t, storedTask(from))
+
+					updated, err := svc.Transition(context.Background(), TransitionInput{
+						TaskID:     1,
+						Actor:      actorFor(role),
+						Permission: policy.ActionTransition,
+						Action:     transition.Action,
+						Note:       "note",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if updated.Status != transition.To || updated.Version != 4 {
+						t.Fatalf("task = status %q version %d, want %q 4", updated.Status, updated.Version, transition.To)
+					}
+
+					histories := tasks.Histories(1)
+					if len(histories) != 1 || histories[0].Action != transition.Action {
+						t.Fatalf("histories = %+v, want one %s", histories, transition.Action)
+					}
+					if len(*events) != 1 || (*events)[0].Type != transition.Action {
+						t.Fatalf("events = %+v, want one %s", *events, transition.Action)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestTransitionRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		actor  policy.Actor
+		action string
+		note   string
+		want   error
+	}{
+		{name: "role not allowed", status: "Submitted", actor: actorFor("pelaksana"), action: "approve", want: workflow.ErrRoleNotAllowed},
+		{name: "other leader", status: "Submitted", actor: policy.Actor{ID: otherLeaderID, Role: "leader"}, action: "approve", want: policy.ErrForbidden},
+		{name: "invalid state", status: "Approved by Leader", actor: actorFor("leader"), action: "approve", want: workflow.ErrInvalidState},
+		{name: "note required", status: "Submitted", actor: actorFor("leader"), action: "revision", want: workflow.ErrNoteRequired},
+		{name: "unknown action", status: "Submitted", actor: actorFor("leader"), action: "archive", want: workflow.ErrUnknownAction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, tasks, events := newTestService(t, storedTask(tt.status))
+
+			_, err := svc.Transition(context.Background(), TransitionInput{
+				TaskID:     1,
+				Actor:      tt.actor,
+				Permission: policy.ActionTransition,
+				Action:     tt.action,
+				Note:       tt.note,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Transition() error = %v, want %v", err, tt.want)
+			}
+
+			task, _ := tasks.Find(context.Background(), 1)
+			if task.Status != tt.status || task.Version != 3 || len(tasks.Histories(1)) != 0 || len(*events) != 0 {
+				t.Fatalf("rejected transition changed state: %+v", task)
+			}
+		})
+	}
+}
+
+func TestTransitionVersionMismatch(t *testing.T) {
+	t.Run("precondition", func(t *testing.T) {
+		svc, tasks, _ := newTestService(t, storedTask("Submitted"))
+
+		current, err := svc.Transition(context.Background(), TransitionInput{
+			TaskID:     1,
+			Actor:      actorFor("leader"),
+			Permission: policy.ActionTransition,
+			Action:     "approve",
+			Precondition: func(task model.Task) error {
+				if task.Version != 2 {
+					return ErrVersionMismatch
+				}
+				return nil
+			},
+		})
+		if !errors.Is(err, ErrVersionMismatch) {
+			t.Fatalf("Transition() error = %v, want %v", err, ErrVersionMismatch)
+		}
+		if current.Version != 3 || len(tasks.Histories(1)) != 0 {
+			t.Fatalf("current = %+v, histories = %d", current, len(tasks.Histories(1)))
+		}
+	})
+
+	t.Run("concurrent update", func(t *testing.T) {
+		svc, tasks, _ := newTestService(t, storedTask("Submitted"))
+		svc.Tasks = staleTaskRepository{svc.Tasks}
+
+		_, err := svc.Transition(context.Background(), TransitionInput{
+			TaskID:     1,
+			Actor:      actorFor("leader"),
+			Permission: policy.ActionTransition,
+			Action:     "approve",
+		})
+		if !errors.Is(err, ErrVersionMismatch) {
+			t.Fatalf("Transition() error = %v, want %v", err, ErrVersionMismatch)
+		}
+		if task, _ := tasks.Find(context.Background(), 1); task.Status != "Submitted" || len(tasks.Histories(1)) != 0 {
+			t.Fatalf("task changed after conflict: %+v", task)
+		}
+	})
+}
+
+func TestReassign(t *testing.T) {
+	escalated := storedTask("Submitted")
+	escalatedAt := testNow.Add(-time.Hour)
+	escalated.EscalatedAt = &escalatedAt
+	escalated.EscalationRule = "leader-review-overdue"
+
+	tests := []struct {
+		name     string
+		task     model.Task
+		actor    policy.Actor
+		leaderID uint
+		want     error
+	}{
+		{name: "reassigned", task: escalated, actor: actorFor("manager"), leaderID: otherLeaderID},
+		{name: "not escalated", task: storedTask("Submitted"), actor: actorFor("manager"), leaderID: otherLeaderID, want: ErrNotEscalated},
+		{name: "same leader", task: escalated, actor: actorFor("manager"), leaderID: leaderID, want: ErrSameLeader},
+		{name: "inactive leader", task: escalated, actor: actorFor("manager"), leaderID: inactiveLeaderID, want: ErrLeaderNotFound},
+		{name: "leader cannot reassign", task: escalated, actor: actorFor("leader"), leaderID: otherLeaderID, want: policy.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, tasks, events := newTestService(t, tt.task)
+
+			updated, err := svc.Reassign(context.Background(), ReassignInput{
+				TaskID:   1,
+				Actor:    tt.actor,
+				LeaderID: tt.leaderID,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Reassign() error = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				return
+			}
+
+			if updated.AssignedLeader != tt.leaderID || updated.EscalatedAt != nil || updated.Version != 4 {
+				t.Fatalf("reassigned task = %+v", updated)
+			}
+			if histories := tasks.Histories(1); len(histories) != 1 || histories[0].Action != "reassign" {
+				t.Fatalf("histories = %+v, want one reassign", histories)
+			}
+			if len(*events) != 1 || (*events)[0].Type != "reassign" {
+				t.Fatalf("events = %+v, want one reassign", *events)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deleted", func(t *testing.T) {
+		svc, tasks, events := newTestService(t, storedTask("Submitted"))
+
+		if _, err := svc.Delete(ctx, DeleteInput{TaskID: 1, Actor: actorFor("pelaksana")}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tasks.Find(ctx, 1); !errors.Is(err, repository.ErrNotFound) {
+			t.Fatalf("Find() after delete error = %v, want %v", err, repository.ErrNotFound)
+		}
+		if histories := tasks.Histories(1); len(histories) != 1 || histories[0].Action != "delete" {
+			t.Fatalf("histories = %+v, want one delete", histories)
+		}
+		if len(*events) != 1 || (*events)[0].Type != "task_deleted" {
+			t.Fatalf("events = %+v, want one task_deleted", *events)
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		svc, tasks, _ := newTestService(t, storedTask("Submitted"))
+
+		if _, err := svc.Delete(ctx, DeleteInput{TaskID: 1, Actor: actorFor("leader")}); !errors.Is(err, policy.ErrForbidden) {
+			t.Fatalf("Delete() as leader error = %v, want %v", err, policy.ErrForbidden)
+		}
+
+		svc.Tasks = staleTaskRepository{svc.Tasks}
+		if _, err := svc.Delete(ctx, DeleteInput{TaskID: 1, Actor: actorFor("pelaksana")}); !errors.Is(err, ErrVersionMismatch) {
+			t.Fatalf("Delete() with stale version error = %v, want %v", err, ErrVersionMismatch)
+		}
+
+		if _, err := tasks.Find(ctx, 1); err != nil || len(tasks.Histories(1)) != 0 {
+			t.Fatalf("task changed after rejected delete: %v", err)
+		}
+	})
+}
+
+func TestListScopesByRole(t *testing.T) {
+	escalatedAt := testNow
+	seed := []model.Task{
+		{ID: 1, CreatedBy: pelaksanaID, AssignedLeader: leaderID, Status: "Submitted"},
+		{ID: 2, CreatedBy: pelaksanaID, AssignedLeader: otherLeaderID, Status: "Approved by Leader"},
+		{ID: 3, CreatedBy: 9, AssignedLeader: leaderID, Status: "Revision"},
+		{ID: 4, CreatedBy: 9, AssignedLeader: leaderID, Status: "Submitted", EscalatedAt: &escalatedAt},
+	}
+
+	tests := []struct {
+		role string
+		want []uint
+	}{
+		{role: "pelaksana", want: []uint{1, 2}},
+		{role: "leader", want: []uint{1, 4}},
+		{role: "manager", want: []uint{2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			svc, _, _ := newTestService(t, seed...)
+
+			tasks, total, err := svc.List(context.Background(), actorFor(tt.role), repository.TaskQuery{
+				Sort: []repository.TaskSort{{Column: "id"}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []uint
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			if total != int64(len(tt.want)) || len(ids) != len(tt.want) {
+				t.Fatalf("List() = %v (total %d), want %v", ids, total, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("List() = %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+
+	svc, _, _ := newTestService(t, seed...)
+	if _, _, err := svc.List(context.Background(), policy.Actor{ID: 7, Role: "guest"}, repository.TaskQuery{}); !errors.Is(err, policy.ErrForbidden) {
+		t.Fatalf("List() as guest error = %v, want %v", err, policy.ErrForbidden)
+	}
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalRoundTrip(t *testing.T) {
+	local, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := local.Put(ctx, "tasks/1/abc", bytes.NewReader([]byte("hello")), 5, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := local.Get(ctx, "tasks/1/abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil || string(data) != "hello" {
+		t.Fatalf("Get = %q, %v", data, err)
+	}
+
+	if err := local.Delete(ctx, "tasks/1/abc"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := local.Get(ctx, "tasks/1/abc"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after delete = %v, want ErrNotFound", err)
+	}
+	if err := local.Delete(ctx, "tasks/1/abc"); err != nil {
+		t.Fatalf("Delete of a missing key = %v, want nil", err)
+	}
+}
+
+func TestLocalRejectsKeysOutsideRoot(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "uploads")
+	local, err := NewLocal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outside := filepath.Join(parent, "secret.txt")
+	if err := os.WriteFile(outside, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sibling := filepath.Join(parent, "uploads-other")
+	if err := os.MkdirAll(sibling, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, key := range []string{
+		"../secret.txt",
+		"tasks/../../secret.txt",
+		"../uploads-other/file",
+		"..",
+		"",
+		".",
+	} {
+		if err := local.Put(ctx, key, bytes.NewReader([]byte("x")), 1, ""); err == nil {
+			t.Errorf("Put(%q) succeeded, want an invalid key error", key)
+		}
+		if body, err := local.Get(ctx, key); err == nil {
+			body.Close()
+			t.Errorf("Get(%q) succeeded, want an invalid key error", key)
+		}
+		if err := local.Delete(ctx, key); err == nil {
+			t.Errorf("Delete(%q) succeeded, want an invalid key error", key)
+		}
+	}
+
+	if data, err := os.ReadFile(outside); err != nil || string(data) != "secret" {
+		t.Fatalf("file outside the root was modified: %q, %v", data, err)
+	}
+	if entries, _ := os.ReadDir(sibling); len(entries) != 0 {
+		t.Fatalf("sibling directory was written to: %v", entries)
+	}
+}
@@ -0,0 +1,275 @@
+package stream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/logging"
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/policy"
+	"gorm.io/gorm"
+)
+
+const (
+	replayPageSize = 500
+	lookbackWindow = 10 * time.Second
+	subscriberSize = 64
+)
+
+type Event struct {
+	ID        uint        `json:"id"`
+	TaskID    uint        `json:"task_id"`
+	Action    string      `json:"action"`
+	Note      string      `json:"note"`
+	ActionBy  *model.User `json:"action_by"`
+	CreatedAt time.Time   `json:"created_at"`
+	Task      model.Task  `json:"task"`
+}
+
+type Subscriber struct {
+	actor  policy.Actor
+	events chan Event
+}
+
+func (s *Subscriber) Events() <-chan Event {
+	return s.events
+}
+
+type Hub struct {
+	db       *gorm.DB
+	interval time.Duration
+
+	mu          sync.Mutex
+	subscribers map[*Subscriber]struct{}
+	cursor      uint
+	seen        map[uint]time.Time
+}
+
+var Default *Hub
+
+func NewHub(db *gorm.DB, interval time.Duration) *Hub {
+	return &Hub{
+		db:          db,
+		interval:    interval,
+		subscribers: map[*Subscriber]struct{}{},
+		seen:        map[uint]time.Time{},
+	}
+}
+
+func (h *Hub) Start(ctx context.Context) error {
+	now := time.Now()
+
+	var latest model.TaskHistory
+	if err := h.db.WithContext(ctx).Order("id DESC").Limit(1).Find(&latest).Error; err != nil {
+		return err
+	}
+	h.cursor = latest.ID
+
+	var recent []uint
+	if err := h.db.WithContext(ctx).Model(&model.TaskHistory{}).
+		Where("created_at >= ?", now.Add(-lookbackWindow)).
+		Pluck("id", &recent).Error; err != nil {
+		return err
+	}
+	for _, id := range recent {
+		h.seen[id] = now
+	}
+
+	go func() {
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				h.closeAll()
+				return
+			case <-ticker.C:
+				if err := h.poll(ctx); err != nil && ctx.Err() == nil {
+					logging.FromContext(ctx).Error("event stream poll failed", "error", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (h *Hub) Subscribe(actor policy.Actor) *Subscriber {
+	sub := &Subscriber{actor: actor, events: make(chan Event, subscriberSize)}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub
+}
+
+func (h *Hub) Unsubscribe(sub *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (h *Hub) Replay(ctx context.Context, actor policy.Actor, after uint, emit func(Event) bool) error {
+	for {
+		var histories []model.TaskHistory
+		if err := visibleTo(h.db.WithContext(ctx), actor).
+			Preload("ActionUser").
+			Where("id > ?", after).
+			Order("id ASC").
+			Limit(replayPageSize).
+			Find(&histories).Error; err != nil {
+			return err
+		}
+
+		events, err := h.events(ctx, histories)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if policy.CanTask(actor, policy.ActionView, &event.Task) != nil {
+				continue
+			}
+			if !emit(event) {
+				return nil
+			}
+		}
+
+		if len(histories) < replayPageSize {
+			return nil
+		}
+		after = histories[len(histories)-1].ID
+	}
+}
+
+func visibleTo(db *gorm.DB, actor policy.Actor) *gorm.DB {
+	tasks := db.Session(&gorm.Session{NewDB: true}).Unscoped().Model(&model.Task{}).Select("id")
+
+	switch actor.Role {
+	case "pelaksana":
+		return db.Where("task_id IN (?)", tasks.Where("created_by = ?", actor.ID))
+	case "leader":
+		return db.Where("task_id IN (?)", tasks.Where("assigned_leader = ?", actor.ID))
+	case "manager":
+		return db
+	}
+	return db.Where("1 = 0")
+}
+
+func (h *Hub) poll(ctx context.Context) error {
+	now := time.Now()
+
+	var histories []model.TaskHistory
+	if err := h.db.WithContext(ctx).
+		Preload("ActionUser").
+		Where("id > ? OR created_at >= ?", h.cursor, now.Add(-lookbackWindow)).
+		Order("id ASC").
+		Find(&histories).Error; err != nil {
+		return err
+	}
+
+	var fresh []model.TaskHistory
+	for _, history := range histories {
+		if _, ok := h.seen[history.ID]; !ok {
+			fresh = append(fresh, history)
+		}
+	}
+
+	for id, at := range h.seen {
+		if at.Before(now.Add(-2 * lookbackWindow)) {
+			delete(h.seen, id)
+		}
+	}
+
+	events, err := h.events(ctx, fresh)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		h.seen[event.ID] = now
+		if event.ID > h.cursor {
+			h.cursor = event.ID
+		}
+		h.broadcast(event)
+	}
+	return nil
+}
+
+func (h *Hub) broadcast(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		if policy.CanTask(sub.actor, policy.ActionView, &event.Task) != nil {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			delete(h.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+func (h *Hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (h *Hub) events(ctx context.Context, histories []model.TaskHistory) ([]Event, error) {
+	if len(histories) == 0 {
+		return nil, nil
+	}
+
+	var taskIDs []uint
+	for _, history := range histories {
+		taskIDs = append(taskIDs, history.TaskID)
+	}
+
+	var tasks []model.Task
+	if err := h.db.WithContext(ctx).
+		Unscoped().
+		Preload("CreatedByUser").
+		Preload("LeaderUser").
+		Preload("ProgressUser").
+		Where("id IN ?", taskIDs).
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]model.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	var events []Event
+	for _, history := range histories {
+		task, ok := byID[history.TaskID]
+		if !ok {
+			continue
+		}
+		events = append(events, Event{
+			ID:        history.ID,
+			TaskID:    history.TaskID,
+			Action:    history.Action,
+			Note:      history.Note,
+			ActionBy:  history.ActionUser,
+			CreatedAt: history.CreatedAt,
+			Task:      task,
+		})
+	}
+	return events, nil
+}
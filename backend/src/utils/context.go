@@ -54,3 +54,19 @@ func GetRoleFromContext(c *gin.Context) (string, error) {
 
 	return roleStr, nil
 }
+
+func GetSessionIDFromContext(c *gin.Context) (uint, error) {
+	sessionID, exists := c.Get("session_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Session not found in token"})
+		return 0, errors.New("session not found in token")
+	}
+
+	sessionIDFloat, ok := sessionID.(float64)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid session ID type"})
+		return 0, errors.New("invalid session ID type")
+	}
+
+	return uint(sessionIDFloat), nil
+}
@@ -1,29 +1,65 @@
 package utils
 
 import (
-	"os"
+	"errors"
+	"time"
 
+	"github.com/ardhia137/task_todo/src/config"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtSecret = []byte(os.Getenv("rahasia123"))
+func jwtSecret() ([]byte, error) {
+	secret := config.Current.Auth.JWTSecret
+	if secret == "" {
+		return nil, errors.New("JWT_SECRET is not set")
+	}
+	return []byte(secret), nil
+}
+
+func AccessTokenTTL() time.Duration {
+	return config.Current.Auth.AccessTokenTTL
+}
+
+func RefreshTokenTTL() time.Duration {
+	return config.Current.Auth.RefreshTokenTTL
+}
 
-func GenerateJWT(userID uint, username string, role string) (string, error) {
+func GenerateJWT(userID uint, username string, role string, sessionID uint) (string, error) {
+	secret, err := jwtSecret()
+	if err != nil {
+		return "", err
+	}
+
+	jti, err := RandomToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"user_id":  userID,
 		"username": username,
 		"role":     role,
+		"sid":      sessionID,
+		"jti":      jti,
+		"iat":      now.Unix(),
+		"exp":      now.Add(AccessTokenTTL()).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	return token.SignedString(secret)
 }
 
 func ValidateJWT(tokenString string) (*jwt.Token, error) {
+	secret, err := jwtSecret()
+	if err != nil {
+		return nil, err
+	}
+
 	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, jwt.ErrSignatureInvalid
 		}
-		return jwtSecret, nil
-	})
+		return secret, nil
+	}, jwt.WithExpirationRequired(), jwt.WithIssuedAt())
 }
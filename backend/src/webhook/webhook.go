@@ -0,0 +1,173 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ardhia137/task_todo/src/config"
+	"github.com/ardhia137/task_todo/src/model"
+	"github.com/ardhia137/task_todo/src/notify"
+	"github.com/ardhia137/task_todo/src/utils"
+	"github.com/ardhia137/task_todo/src/workflow"
+	"gorm.io/gorm"
+)
+
+const (
+	AllEvents       = "*"
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+
+	maxResponseBody = 4 << 10
+)
+
+var SystemEvents = []string{
+	"deadline_reminder",
+	"overdue",
+	"escalate",
+	"reassign",
+	"task_deleted",
+	"comment_created",
+	"comment_updated",
+	"attachment_uploaded",
+}
+
+func KnownEvent(def *workflow.Definition, name string) bool {
+	if name == AllEvents || slices.Contains(SystemEvents, name) {
+		return true
+	}
+	_, ok := def.Find(name)
+	return ok
+}
+
+type Payload struct {
+	ID         string     `json:"id"`
+	Event      string     `json:"event"`
+	OccurredAt time.Time  `json:"occurred_at"`
+	ActorID    *uint      `json:"actor_id"`
+	Note       string     `json:"note,omitempty"`
+	Task       model.Task `json:"task"`
+}
+
+type Result struct {
+	Status   int
+	Body     string
+	Duration time.Duration
+}
+
+func Subscribed(hook model.Webhook, eventType string) bool {
+	return slices.Contains(hook.Events, AllEvents) || slices.Contains(hook.Events, eventType)
+}
+
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+var client = &http.Client{}
+
+func Send(ctx context.Context, hook model.Webhook, delivery model.WebhookDelivery) (Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.Current.Webhook.Timeout)
+	defer cancel()
+
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-todo-webhooks/1.0")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.EventID)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, timestamp, body))
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{Duration: time.Since(start)}, err
+	}
+	defer resp.Body.Close()
+
+	response, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	result := Result{Status: resp.StatusCode, Body: string(response), Duration: time.Since(start)}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return result, fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+	return result, nil
+}
+
+type Notifier struct {
+	DB *gorm.DB
+}
+
+func (n Notifier) Notify(ctx context.Context, event notify.Event) error {
+	db := n.DB.WithContext(ctx)
+
+	var hooks []model.Webhook
+	if err := db.Where("active = ?", true).Find(&hooks).Error; err != nil {
+		return err
+	}
+	hooks = slices.DeleteFunc(hooks, func(hook model.Webhook) bool {
+		return !Subscribed(hook, event.Type)
+	})
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	task := event.Task
+	if err := db.
+		Preload("CreatedByUser").
+		Preload("LeaderUser").
+		Preload("ProgressUser").
+		First(&task, event.Task.ID).Error; err != nil {
+		task = event.Task
+	}
+	task.TaskHistories = nil
+
+	var deliveries []model.WebhookDelivery
+	for _, hook := range hooks {
+		eventID, err := utils.RandomToken(16)
+		if err != nil {
+			return err
+		}
+
+		body, err := json.Marshal(Payload{
+			ID:         eventID,
+			Event:      event.Type,
+			OccurredAt: event.OccurredAt,
+			ActorID:    event.ActorID,
+			Note:       event.Note,
+			Task:       task,
+		})
+		if err != nil {
+			return err
+		}
+
+		deliveries = append(deliveries, model.WebhookDelivery{
+			WebhookID:     hook.ID,
+			EventID:       eventID,
+			EventType:     event.Type,
+			Payload:       string(body),
+			NextAttemptAt: event.OccurredAt,
+		})
+	}
+
+	return db.Create(&deliveries).Error
+}
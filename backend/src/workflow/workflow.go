@@ -0,0 +1,194 @@
+package workflow
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
+
+//go:embed default_workflow.json
+var defaultDefinition []byte
+
+var (
+	ErrUnknownAction   = errors.New("unknown workflow action")
+	ErrInvalidState    = errors.New("action not allowed in current state")
+	ErrRoleNotAllowed  = errors.New("role not allowed to perform action")
+	ErrNoteRequired    = errors.New("note is required for this action")
+	ErrInternalAction  = errors.New("action must be performed through its dedicated endpoint")
+	ErrInvalidWorkflow = errors.New("invalid workflow definition")
+)
+
+var (
+	Roles           = []string{"pelaksana", "leader", "manager"}
+	RequiredActions = []string{"submit", "update_progress", "complete"}
+)
+
+type State struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles,omitempty"`
+	Final bool     `json:"final,omitempty"`
+}
+
+type Transition struct {
+	Action       string   `json:"action"`
+	From         []string `json:"from"`
+	To           string   `json:"to"`
+	Roles        []string `json:"roles"`
+	NoteRequired bool     `json:"note_required,omitempty"`
+	Internal     bool     `json:"internal,omitempty"`
+}
+
+type Definition struct {
+	Initial     string       `json:"initial"`
+	States      []State      `json:"states"`
+	Transitions []Transition `json:"transitions"`
+}
+
+var Current *Definition
+
+func Load(path string) error {
+	data := defaultDefinition
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read workflow file: %w", err)
+		}
+	}
+
+	def, err := Parse(data)
+	if err != nil {
+		return err
+	}
+
+	Current = def
+	return nil
+}
+
+func Parse(data []byte) (*Definition, error) {
+	var def Definition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("parse workflow: %w", err)
+	}
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+func (d *Definition) Validate() error {
+	if !d.HasState(d.Initial) {
+		return fmt.Errorf("%w: initial state '%s' is not declared", ErrInvalidWorkflow, d.Initial)
+	}
+
+	for _, s := range d.States {
+		for _, role := range s.Roles {
+			if !slices.Contains(Roles, role) {
+				return fmt.Errorf("%w: state '%s' uses unknown role '%s'", ErrInvalidWorkflow, s.Name, role)
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, t := range d.Transitions {
+		if t.Action == "" {
+			return fmt.Errorf("%w: transition without action", ErrInvalidWorkflow)
+		}
+		if seen[t.Action] {
+			return fmt.Errorf("%w: duplicate action '%s'", ErrInvalidWorkflow, t.Action)
+		}
+		seen[t.Action] = true
+
+		if !d.HasState(t.To) {
+			return fmt.Errorf("%w: action '%s' targets unknown state '%s'", ErrInvalidWorkflow, t.Action, t.To)
+		}
+		for _, from := range t.From {
+			if !d.HasState(from) {
+				return fmt.Errorf("%w: action '%s' starts from unknown state '%s'", ErrInvalidWorkflow, t.Action, from)
+			}
+		}
+		if len(t.Roles) == 0 {
+			return fmt.Errorf("%w: action '%s' has no roles", ErrInvalidWorkflow, t.Action)
+		}
+		for _, role := range t.Roles {
+			if !slices.Contains(Roles, role) {
+				return fmt.Errorf("%w: action '%s' uses unknown role '%s'", ErrInvalidWorkflow, t.Action, role)
+			}
+		}
+	}
+
+	for _, action := range RequiredActions {
+		if !seen[action] {
+			return fmt.Errorf("%w: required action '%s' is not declared", ErrInvalidWorkflow, action)
+		}
+	}
+	return nil
+}
+
+func (d *Definition) HasState(name string) bool {
+	for _, s := range d.States {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Definition) Find(action string) (*Transition, bool) {
+	for i := range d.Transitions {
+		if d.Transitions[i].Action == action {
+			return &d.Transitions[i], true
+		}
+	}
+	return nil, false
+}
+
+func (d *Definition) Fire(from, action, role, note string) (*Transition, error) {
+	t, ok := d.Find(action)
+	if !ok {
+		return nil, ErrUnknownAction
+	}
+	if !slices.Contains(t.Roles, role) {
+		return t, ErrRoleNotAllowed
+	}
+	if !slices.Contains(t.From, from) {
+		return t, ErrInvalidState
+	}
+	if t.NoteRequired && note == "" {
+		return t, ErrNoteRequired
+	}
+	return t, nil
+}
+
+func (d *Definition) Available(from, role string) []Transition {
+	var available []Transition
+	for _, t := range d.Transitions {
+		if slices.Contains(t.From, from) && slices.Contains(t.Roles, role) {
+			available = append(available, t)
+		}
+	}
+	return available
+}
+
+func (d *Definition) StatesFor(role string) []string {
+	var states []string
+	for _, s := range d.States {
+		if slices.Contains(s.Roles, role) {
+			states = append(states, s.Name)
+		}
+	}
+	return states
+}
+
+func (d *Definition) OpenStates() []string {
+	var states []string
+	for _, s := range d.States {
+		if !s.Final {
+			states = append(states, s.Name)
+		}
+	}
+	return states
+}
@@ -1,9 +1,16 @@
 
 const API_URL = 'http://localhost:8080/tasks/approved';
+const TASKS_URL = 'http://localhost:8080/tasks/';
+const EVENTS_URL = 'http://localhost:8080/events/stream';
+const CONFLICT_MESSAGE = 'Tugas telah diubah oleh pengguna lain. Data dimuat ulang, silakan coba lagi.';
+const taskVersions = {};
+const PAGE_SIZE = 20;
+let currentPage = 1;
 const TOKEN_KEY = localStorage.getItem('authToken') ? 'authToken' : 'token';
 
 document.addEventListener('DOMContentLoaded', () => {
     fetchApprovedTasks();
+    subscribeTaskEvents(fetchApprovedTasks);
     document.getElementById('logoutButton').addEventListener('click', () => {
         localStorage.removeItem(TOKEN_KEY);
         localStorage.removeItem('userRole');
@@ -21,7 +28,7 @@ async function fetchApprovedTasks() {
     }
 
     try {
-        const response = await fetch(API_URL, {
+        const response = await fetch(`${API_URL}?page=${currentPage}&page_size=${PAGE_SIZE}`, {
             method: 'GET',
             headers: {
                 'Authorization': `Bearer ${token}`,
@@ -43,22 +50,30 @@ async function fetchApprovedTasks() {
 
         const data = await response.json();
 
-        const tasks = data.tasks || []; 
-        renderDashboard(tasks);
+        const tasks = data.tasks || [];
+        const pagination = data.pagination;
+        if (pagination.total_pages > 0 && currentPage > pagination.total_pages) {
+            currentPage = pagination.total_pages;
+            return fetchApprovedTasks();
+        }
+        renderDashboard(tasks, pagination);
     } catch (error) {
         console.error('Gagal mengambil data:', error);
         document.getElementById('kpi-container').innerHTML = `<div class="col-12"><p class="text-center text-danger">Gagal memuat data: ${error.message}</p></div>`;
     }
 }
 
-function renderDashboard(tasks) {
-    renderKpi(tasks);
+function renderDashboard(tasks, pagination) {
+    rememberTaskVersions(tasks);
+    renderKpi(tasks, pagination.total);
+    renderPagination(pagination, fetchApprovedTasks);
     renderTaskTable(tasks);
     renderTaskModals(tasks);
+    setupActionListeners();
 }
 
-function renderKpi(tasks) {
-    const totalTasks = tasks.length;
+function renderKpi(tasks, total) {
+    const totalTasks = total;
     const inProgressTasks = tasks.filter(t => t.status.toLowerCase() === 'in progress').length;
     const activeTasks = tasks.filter(t => t.status.toLowerCase() !== 'completed');
     const totalProgress = activeTasks.reduce((sum, t) => sum + t.progress, 0);
@@ -98,7 +113,7 @@ function renderTaskTable(tasks) {
                     <tr>
                         <td><div class="fw-bold">${task.title} (ID: ${task.id})</div></td>
                         <td>${task.assigned_leader.username}</td>
-                        <td>${statusBadge}</td>
+                        <td>${statusBadge}${task.escalated_at ? ' <span class="badge bg-danger">Eskalasi</span>' : ''}</td>
                         <td>
                             <div class="progress-bar-container d-flex align-items-center">
                                 <span class="fw-bold me-2">${task.progress}%</span>
@@ -112,6 +127,7 @@ function renderTaskTable(tasks) {
                             <button type="button" class="btn btn-sm btn-outline-primary" data-bs-toggle="modal" data-bs-target="#taskDetailModal${task.id}">
                                 Lihat Detail
                             </button>
+                            ${getManagerActions(task)}
                         </td>
                     </tr>
                 `;
@@ -124,35 +140,13 @@ function renderTaskModals(tasks) {
     modalContainer.innerHTML = '';
 
     tasks.forEach(task => {
-        let historyHtml = '';
-        if (task.histories && task.histories.length > 0) {
-            task.histories.forEach(history => {
-                const historyDate = new Date(history.created_at).toLocaleString('id-ID', {
-                    day: '2-digit', month: 'short', year: 'numeric', hour: '2-digit', minute: '2-digit'
-                });
-                const historyBadge = getHistoryBadge(history.action);
-                const noteHtml = history.note ? `<p class="mb-1">"${history.note}"</p>` : '';
-
-                historyHtml += `
-                            <div class="history-item ${history.action}">
-                                ${historyBadge}
-                                <strong>(oleh: ${history.action_by.username})</strong>
-                                ${noteHtml}
-                                <p class="mb-0 small text-muted">${historyDate}</p>
-                            </div>
-                        `;
-            });
-        } else {
-            historyHtml = '<p>Belum ada histori.</p>';
-        }
-
         const deadline = new Date(task.deadline).toLocaleDateString('id-ID', {
             day: '2-digit', month: 'short', year: 'numeric'
         });
         const statusBadge = getStatusBadge(task.status);
 
         const modalHtml = `
-                    <div class="modal fade" id="taskDetailModal${task.id}" tabindex="-1" aria-labelledby="taskDetailModalLabel${task.id}" aria-hidden="true">
+                    <div class="modal fade" id="taskDetailModal${task.id}" data-task-id="${task.id}" tabindex="-1" aria-labelledby="taskDetailModalLabel${task.id}" aria-hidden="true">
                         <div class="modal-dialog modal-lg modal-dialog-centered">
                             <div class="modal-content">
                                 <div class="modal-header">
@@ -181,7 +175,7 @@ function renderTaskModals(tasks) {
                                     <hr>
                                     <h6 class="mb-3">Histori Aktivitas</h6>
                                     <div class="history-log">
-                                        ${historyHtml}
+                                        <p class="text-muted">Memuat histori...</p>
                                     </div>
                                 </div>
                                 <div class="modal-footer">
@@ -193,6 +187,7 @@ function renderTaskModals(tasks) {
                 `;
         modalContainer.innerHTML += modalHtml;
     });
+    loadHistoriesOnOpen(modalContainer);
 }
 
 
@@ -206,6 +201,12 @@ function getStatusBadge(status) {
             return `<span class="badge bg-warning text-dark">Pending</span>`;
         case 'revision':
             return `<span class="badge bg-danger">Revision</span>`;
+        case 'approved by leader':
+            return `<span class="badge bg-primary">Approved by Leader</span>`;
+        case 'approved by manager':
+            return `<span class="badge bg-success">Approved by Manager</span>`;
+        case 'rejected':
+            return `<span class="badge bg-dark">Rejected</span>`;
         default:
             return `<span class="badge bg-secondary">${status}</span>`;
     }
@@ -219,9 +220,197 @@ function getHistoryBadge(action) {
             return `<span class="badge bg-warning me-2">Revision</span>`;
         case 'approve':
             return `<span class="badge bg-success me-2">Approve</span>`;
+        case 'manager_approve':
+            return `<span class="badge bg-success me-2">Manager Approve</span>`;
+        case 'return':
+            return `<span class="badge bg-warning me-2">Return</span>`;
+        case 'reject':
+            return `<span class="badge bg-danger me-2">Reject</span>`;
         case 'update_progress':
             return `<span class="badge bg-secondary me-2">Update</span>`;
+        case 'escalate':
+            return `<span class="badge bg-danger me-2">Eskalasi</span>`;
+        case 'reassign':
+            return `<span class="badge bg-info me-2">Reassign</span>`;
         default:
             return `<span class="badge bg-dark me-2">${action}</span>`;
     }
-}
\ No newline at end of file
+}
+
+function getManagerActions(task) {
+    if (task.status.toLowerCase() !== 'approved by leader') {
+        return '';
+    }
+    return `
+        <button type="button" class="btn btn-sm btn-outline-success ms-1 action-manager" data-task-id="${task.id}" data-action="manager-approve">Setujui</button>
+        <button type="button" class="btn btn-sm btn-outline-warning ms-1 action-manager" data-task-id="${task.id}" data-action="return">Kembalikan</button>
+        <button type="button" class="btn btn-sm btn-outline-danger ms-1 action-manager" data-task-id="${task.id}" data-action="reject">Tolak</button>
+    `;
+}
+
+function setupActionListeners() {
+    document.querySelectorAll('.action-manager').forEach(button => {
+        button.addEventListener('click', () => {
+            handleManagerAction(button.getAttribute('data-task-id'), button.getAttribute('data-action'));
+        });
+    });
+}
+
+async function handleManagerAction(taskId, action) {
+    const token = localStorage.getItem(TOKEN_KEY);
+    if (!token) {
+        window.location.href = '../index.html';
+        return;
+    }
+
+    let note = '';
+    if (action === 'manager-approve') {
+        if (!confirm(`Setujui tugas ID: ${taskId}?`)) return;
+    } else {
+        note = prompt(`Catatan untuk tugas ID: ${taskId} (wajib diisi):`);
+        if (!note) return;
+    }
+
+    try {
+        const response = await fetch(`${TASKS_URL}${taskId}/${action}`, {
+            method: 'PUT',
+            headers: {
+                ...versionHeaders(taskId),
+                'Authorization': `Bearer ${token}`,
+                'Content-Type': 'application/json'
+            },
+            body: JSON.stringify({ note })
+        });
+
+        if (!response.ok) {
+            if (response.status === 412) {
+                fetchApprovedTasks();
+                throw new Error(CONFLICT_MESSAGE);
+            }
+            const errorData = await response.json().catch(() => ({}));
+            throw new Error(errorData.error || `HTTP error! Status: ${response.status}`);
+        }
+
+        fetchApprovedTasks();
+    } catch (error) {
+        alert(`Gagal memproses tugas: ${error.message}`);
+    }
+}
+
+function subscribeTaskEvents(onChange) {
+    const token = localStorage.getItem(TOKEN_KEY);
+    if (!token || !window.EventSource) {
+        return;
+    }
+    let refreshTimer = null;
+    const source = new EventSource(`${EVENTS_URL}?access_token=${encodeURIComponent(token)}`);
+    source.addEventListener('task', () => {
+        clearTimeout(refreshTimer);
+        refreshTimer = setTimeout(onChange, 300);
+    });
+    source.onerror = () => {
+        if (source.readyState === EventSource.CLOSED) {
+            console.error('Koneksi stream event terputus.');
+        }
+    };
+}
+
+function rememberTaskVersions(tasks) {
+    tasks.forEach(task => {
+        taskVersions[task.id] = task.version;
+    });
+}
+
+function versionHeaders(taskId) {
+    const version = taskVersions[taskId];
+    return version ? { 'If-Match': `"${taskId}-${version}"` } : {};
+}
+
+function renderHistoryChanges(changes) {
+    if (!changes || changes.length === 0) {
+        return '';
+    }
+    const items = changes.map(change =>
+        `<li><strong>${change.field}</strong>: <del>${change.old || '-'}</del> &rarr; ${change.new || '-'}</li>`
+    ).join('');
+    return `<ul class="small mb-1 ps-3">${items}</ul>`;
+}
+
+function renderPagination(pagination, onPageChange) {
+    const nav = document.getElementById('task-pagination');
+    if (!nav) return;
+
+    const totalPages = Math.max(pagination.total_pages, 1);
+    nav.innerHTML = `
+        <small class="text-muted">Halaman ${pagination.page} dari ${totalPages} (${pagination.total} tugas)</small>
+        <div class="btn-group">
+            <button type="button" class="btn btn-sm btn-outline-secondary" data-page="${pagination.page - 1}" ${pagination.page <= 1 ? 'disabled' : ''}>Sebelumnya</button>
+            <button type="button" class="btn btn-sm btn-outline-secondary" data-page="${pagination.page + 1}" ${pagination.page >= totalPages ? 'disabled' : ''}>Berikutnya</button>
+        </div>`;
+
+    nav.querySelectorAll('button[data-page]').forEach(button => {
+        button.addEventListener('click', () => {
+            currentPage = Number(button.dataset.page);
+            onPageChange();
+        });
+    });
+}
+
+function loadHistoriesOnOpen(modalContainer) {
+    modalContainer.querySelectorAll('.modal[data-task-id]').forEach(modalEl => {
+        modalEl.addEventListener('show.bs.modal', () => {
+            renderTaskHistories(modalEl.dataset.taskId, modalEl.querySelector('.history-log'));
+        });
+    });
+}
+
+async function fetchTaskHistories(taskId) {
+    const token = localStorage.getItem(TOKEN_KEY);
+    const histories = [];
+    let page = 1;
+    let totalPages = 1;
+
+    do {
+        const response = await fetch(`${TASKS_URL}${taskId}/histories?page=${page}&page_size=100`, {
+            headers: { 'Authorization': `Bearer ${token}` }
+        });
+        if (!response.ok) throw new Error(`HTTP error! status: ${response.status}`);
+
+        const data = await response.json();
+        histories.push(...(data.histories || []));
+        totalPages = data.pagination.total_pages;
+        page++;
+    } while (page <= totalPages);
+
+    return histories;
+}
+
+async function renderTaskHistories(taskId, historyLog) {
+    historyLog.innerHTML = '<p class="text-muted">Memuat histori...</p>';
+    try {
+        const histories = await fetchTaskHistories(taskId);
+        historyLog.innerHTML = histories.length > 0
+            ? histories.map(renderHistoryItem).join('')
+            : '<p>Belum ada histori.</p>';
+    } catch (error) {
+        console.error('Gagal mengambil histori tugas:', error);
+        historyLog.innerHTML = '<p class="text-danger">Gagal memuat histori.</p>';
+    }
+}
+
+function renderHistoryItem(history) {
+    const historyDate = new Date(history.created_at).toLocaleString('id-ID', {
+        day: '2-digit', month: 'short', year: 'numeric', hour: '2-digit', minute: '2-digit'
+    });
+    const historyBadge = getHistoryBadge(history.action);
+    const noteHtml = history.note ? `<p class="mb-1">"${history.note}"</p>` : '';
+    return `
+        <div class="history-item ${history.action}">
+            ${historyBadge}
+            <strong>(oleh: ${history.action_by ? history.action_by.username : 'sistem'})</strong>
+            ${noteHtml}
+            ${renderHistoryChanges(history.changes)}
+            <p class="mb-0 small text-muted">${historyDate}</p>
+        </div>
+    `;
+}
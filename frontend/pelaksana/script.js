@@ -1,5 +1,10 @@
 const TOKEN_KEY = 'authToken';
 const API_URL = 'http://localhost:8080/tasks/';
+const EVENTS_URL = 'http://localhost:8080/events/stream';
+const CONFLICT_MESSAGE = 'Tugas telah diubah oleh pengguna lain. Data dimuat ulang, silakan coba lagi.';
+const taskVersions = {};
+const PAGE_SIZE = 20;
+let currentPage = 1;
 
 document.addEventListener('DOMContentLoaded', () => {
     const tooltipTriggerList = [].slice.call(document.querySelectorAll(
@@ -8,6 +13,7 @@ document.addEventListener('DOMContentLoaded', () => {
 
     loadAndRenderTasks();
     loadAndPopulateLeaders();
+    subscribeTaskEvents(loadAndRenderTasks);
 
     document.getElementById('logoutButton').addEventListener('click', () => {
         localStorage.removeItem(TOKEN_KEY);
@@ -36,9 +42,15 @@ async function loadAndPopulateLeaders() {
 }
 
 async function loadAndRenderTasks() {
-    const tasks = await fetchTasks();
-    if (tasks) {
-        renderKpi(tasks);
+    const data = await fetchTasks();
+    if (data) {
+        const { tasks, pagination } = data;
+        if (pagination.total_pages > 0 && currentPage > pagination.total_pages) {
+            currentPage = pagination.total_pages;
+            return loadAndRenderTasks();
+        }
+        renderKpi(tasks, pagination.total);
+        renderPagination(pagination, loadAndRenderTasks);
         renderTable(tasks);
         renderModals(tasks);
         setupActionListeners(tasks);
@@ -85,7 +97,7 @@ async function fetchTasks() {
         return null;
     }
     try {
-        const response = await fetch(API_URL, {
+        const response = await fetch(`${API_URL}?page=${currentPage}&page_size=${PAGE_SIZE}`, {
             method: 'GET',
             headers: {
                 'Authorization': `Bearer ${token}`,
@@ -100,16 +112,15 @@ async function fetchTasks() {
         if (!response.ok) {
             throw new Error(`HTTP error! Status: ${response.status}`);
         }
-        const data = await response.json();
-        return data.tasks;
+        return await response.json();
     } catch (error) {
         console.error('Gagal mengambil data tugas:', error);
         return null;
     }
 }
 
-function renderKpi(tasks) {
-    const totalTasks = tasks.length;
+function renderKpi(tasks, total) {
+    const totalTasks = total;
     const inProgressTasks = tasks.filter(t => t.status.toLowerCase() === 'in progress').length;
     const activeTasks = tasks.filter(t => t.status.toLowerCase() !== 'completed');
     const totalProgress = activeTasks.reduce((sum, t) => sum + t.progress, 0);
@@ -129,6 +140,7 @@ function renderKpi(tasks) {
 }
 
 function renderTable(tasks) {
+    rememberTaskVersions(tasks);
     const tableBody = document.getElementById('task-table-body');
     tableBody.innerHTML = '';
 
@@ -146,7 +158,7 @@ function renderTable(tasks) {
         const taskStatus = task.status.toLowerCase();
 
         let actionButtonEdit = '';
-        if (taskStatus === 'in progress' || taskStatus === 'approved by leader') {
+        if (taskStatus === 'in progress' || taskStatus === 'approved by manager') {
             actionButtonEdit = `
                 <a href="#" class="action-icon text-success me-2 action-update"
                    data-task-id="${task.id}"
@@ -242,27 +254,8 @@ function renderModals(tasks) {
     modalContainer.innerHTML = '';
 
     tasks.forEach(task => {
-        let historyHtml = '';
-        if (task.histories && task.histories.length > 0) {
-            historyHtml = task.histories.map(history => {
-                const historyDate = new Date(history.created_at).toLocaleString('id-ID');
-                const historyBadge = getHistoryBadge(history.action);
-                const noteHtml = history.note ? `<p class="mb-1">"${history.note}"</p>` : '';
-                return `
-                    <div class="history-item ${history.action.toLowerCase()}">
-                        ${historyBadge}
-                        <strong>(oleh: ${history.action_by.username})</strong>
-                        ${noteHtml}
-                        <p class="mb-0 small text-muted">${historyDate}</p>
-                    </div>
-                `;
-            }).join('');
-        }
-
-        if (historyHtml.length === 0) historyHtml = '<p>Belum ada histori.</p>';
-
         const modalHtml = `
-            <div class="modal fade" id="taskDetailModal${task.id}" tabindex="-1" aria-labelledby="taskDetailModalLabel${task.id}" aria-hidden="true">
+            <div class="modal fade" id="taskDetailModal${task.id}" data-task-id="${task.id}" tabindex="-1" aria-labelledby="taskDetailModalLabel${task.id}" aria-hidden="true">
                 <div class="modal-dialog modal-lg modal-dialog-centered">
                     <div class="modal-content">
                         <div class="modal-header">
@@ -284,7 +277,7 @@ function renderModals(tasks) {
                             <strong>Progress: ${task.progress}%</strong>
                             <div class="progress mb-4"><div class="progress-bar" role="progressbar" style="width: ${task.progress}%;"></div></div>
                             <hr><h6 class="mb-3">Histori Aktivitas</h6>
-                            <div class="history-log">${historyHtml}</div>
+                            <div class="history-log"><p class="text-muted">Memuat histori...</p></div>
                         </div>
                         <div class="modal-footer">
                             <button type="button" class="btn btn-secondary" data-bs-dismiss="modal">Tutup</button>
@@ -295,6 +288,7 @@ function renderModals(tasks) {
         `;
         modalContainer.innerHTML += modalHtml;
     });
+    loadHistoriesOnOpen(modalContainer);
 }
 
 function setupActionListeners(tasks) {
@@ -382,6 +376,10 @@ function getStatusBadge(status) {
         case 'pending': return `<span class="badge bg-warning text-dark">Pending</span>`;
         case 'revision': return `<span class="badge bg-danger">Revision</span>`;
         case 'submitted': return `<span class="badge bg-primary">Submitted</span>`;
+        case 'approved by leader': return `<span class="badge bg-primary">Approved by Leader</span>`;
+        case 'returned to leader': return `<span class="badge bg-warning text-dark">Returned to Leader</span>`;
+        case 'approved by manager': return `<span class="badge bg-success">Approved by Manager</span>`;
+        case 'rejected': return `<span class="badge bg-dark">Rejected</span>`;
         default: return `<span class="badge bg-secondary">${status || 'N/A'}</span>`;
     }
 }
@@ -392,6 +390,9 @@ function getHistoryBadge(action) {
         case 'submit': return `<span class="badge bg-info me-2">Submit</span>`;
         case 'revision': return `<span class="badge bg-warning me-2">Revision</span>`;
         case 'approve': return `<span class="badge bg-success me-2">Approve</span>`;
+        case 'manager_approve': return `<span class="badge bg-success me-2">Manager Approve</span>`;
+        case 'return': return `<span class="badge bg-warning me-2">Return</span>`;
+        case 'reject': return `<span class="badge bg-danger me-2">Reject</span>`;
         case 'update_progress': return `<span class="badge bg-secondary me-2">Update</span>`;
         default: return `<span class="badge bg-dark me-2">${action || 'N/A'}</span>`;
     }
@@ -492,10 +493,14 @@ async function handleEditTask(event) {
     try {
         const response = await fetch(`${API_URL}${taskId}`, {
             method: 'PUT',
-            headers: { 'Authorization': `Bearer ${token}`, 'Content-Type': 'application/json' },
+            headers: { ...versionHeaders(taskId), 'Authorization': `Bearer ${token}`, 'Content-Type': 'application/json' },
             body: JSON.stringify(requestBody)
         });
         if (!response.ok) {
+            if (response.status === 412) {
+                loadAndRenderTasks();
+                throw new Error(CONFLICT_MESSAGE);
+            }
             const errorData = await response.json().catch(() => ({}));
             throw new Error(errorData.message || `Gagal menyimpan revisi. Status: ${response.status}`);
         }
@@ -545,9 +550,13 @@ async function handleDeleteTask() {
     try {
         const response = await fetch(`${API_URL}${taskId}`, {
             method: 'DELETE',
-            headers: { 'Authorization': `Bearer ${token}` }
+            headers: { ...versionHeaders(taskId), 'Authorization': `Bearer ${token}` }
         });
         if (!response.ok) {
+            if (response.status === 412) {
+                loadAndRenderTasks();
+                throw new Error(CONFLICT_MESSAGE);
+            }
             const errorData = await response.json().catch(() => ({}));
             throw new Error(errorData.message || `Gagal menghapus tugas. Status: ${response.status}`);
         }
@@ -599,10 +608,14 @@ async function handleUpdateProgress(event) {
     try {
         const response = await fetch(`${API_URL}${taskId}/progress`, {
             method: 'PUT',
-            headers: { 'Authorization': `Bearer ${token}`, 'Content-Type': 'application/json' },
+            headers: { ...versionHeaders(taskId), 'Authorization': `Bearer ${token}`, 'Content-Type': 'application/json' },
             body: JSON.stringify(requestBody)
         });
         if (!response.ok) {
+            if (response.status === 412) {
+                loadAndRenderTasks();
+                throw new Error(CONFLICT_MESSAGE);
+            }
             const errorData = await response.json().catch(() => ({}));
             throw new Error(errorData.message || `Gagal update progress. Status: ${response.status}`);
         }
@@ -629,4 +642,120 @@ async function handleUpdateProgress(event) {
             confirmButtonText: 'OK'
         });
     }
-}
\ No newline at end of file
+}
+
+function subscribeTaskEvents(onChange) {
+    const token = localStorage.getItem(TOKEN_KEY);
+    if (!token || !window.EventSource) {
+        return;
+    }
+    let refreshTimer = null;
+    const source = new EventSource(`${EVENTS_URL}?access_token=${encodeURIComponent(token)}`);
+    source.addEventListener('task', () => {
+        clearTimeout(refreshTimer);
+        refreshTimer = setTimeout(onChange, 300);
+    });
+    source.onerror = () => {
+        if (source.readyState === EventSource.CLOSED) {
+            console.error('Koneksi stream event terputus.');
+        }
+    };
+}
+
+function rememberTaskVersions(tasks) {
+    tasks.forEach(task => {
+        taskVersions[task.id] = task.version;
+    });
+}
+
+function versionHeaders(taskId) {
+    const version = taskVersions[taskId];
+    return version ? { 'If-Match': `"${taskId}-${version}"` } : {};
+}
+
+function renderHistoryChanges(changes) {
+    if (!changes || changes.length === 0) {
+        return '';
+    }
+    const items = changes.map(change =>
+        `<li><strong>${change.field}</strong>: <del>${change.old || '-'}</del> &rarr; ${change.new || '-'}</li>`
+    ).join('');
+    return `<ul class="small mb-1 ps-3">${items}</ul>`;
+}
+
+function renderPagination(pagination, onPageChange) {
+    const nav = document.getElementById('task-pagination');
+    if (!nav) return;
+
+    const totalPages = Math.max(pagination.total_pages, 1);
+    nav.innerHTML = `
+        <small class="text-muted">Halaman ${pagination.page} dari ${totalPages} (${pagination.total} tugas)</small>
+        <div class="btn-group">
+            <button type="button" class="btn btn-sm btn-outline-secondary" data-page="${pagination.page - 1}" ${pagination.page <= 1 ? 'disabled' : ''}>Sebelumnya</button>
+            <button type="button" class="btn btn-sm btn-outline-secondary" data-page="${pagination.page + 1}" ${pagination.page >= totalPages ? 'disabled' : ''}>Berikutnya</button>
+        </div>`;
+
+    nav.querySelectorAll('button[data-page]').forEach(button => {
+        button.addEventListener('click', () => {
+            currentPage = Number(button.dataset.page);
+            onPageChange();
+        });
+    });
+}
+
+function loadHistoriesOnOpen(modalContainer) {
+    modalContainer.querySelectorAll('.modal[data-task-id]').forEach(modalEl => {
+        modalEl.addEventListener('show.bs.modal', () => {
+            renderTaskHistories(modalEl.dataset.taskId, modalEl.querySelector('.history-log'));
+        });
+    });
+}
+
+async function fetchTaskHistories(taskId) {
+    const token = localStorage.getItem(TOKEN_KEY);
+    const histories = [];
+    let page = 1;
+    let totalPages = 1;
+
+    do {
+        const response = await fetch(`${API_URL}${taskId}/histories?page=${page}&page_size=100`, {
+            headers: { 'Authorization': `Bearer ${token}` }
+        });
+        if (!response.ok) throw new Error(`HTTP error! status: ${response.status}`);
+
+        const data = await response.json();
+        histories.push(...(data.histories || []));
+        totalPages = data.pagination.total_pages;
+        page++;
+    } while (page <= totalPages);
+
+    return histories;
+}
+
+async function renderTaskHistories(taskId, historyLog) {
+    historyLog.innerHTML = '<p class="text-muted">Memuat histori...</p>';
+    try {
+        const histories = await fetchTaskHistories(taskId);
+        historyLog.innerHTML = histories.length > 0
+            ? histories.map(renderHistoryItem).join('')
+            : '<p>Belum ada histori.</p>';
+    } catch (error) {
+        console.error('Gagal mengambil histori tugas:', error);
+        historyLog.innerHTML = '<p class="text-danger">Gagal memuat histori.</p>';
+    }
+}
+
+function renderHistoryItem(history) {
+    const historyDate = new Date(history.created_at).toLocaleString('id-ID');
+    const historyBadge = getHistoryBadge(history.action);
+    const noteHtml = history.note ? `<p class="mb-1">"${history.note}"</p>` : '';
+    return `
+        <div class="history-item ${history.action.toLowerCase()}">
+            ${historyBadge}
+            <strong>(oleh: ${history.action_by ? history.action_by.username : 'sistem'})</strong>
+            ${noteHtml}
+            ${renderHistoryChanges(history.changes)}
+            <p class="mb-0 small text-muted">${historyDate}</p>
+        </div>
+    `;
+}
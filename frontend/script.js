@@ -32,6 +32,7 @@ loginForm.addEventListener('submit', async function (event) {
         
 
         localStorage.setItem('authToken', data.token);
+        localStorage.setItem('refreshToken', data.refresh_token);
         localStorage.setItem('userRole', data.role);
 
         if (data.role === 'manager') {